@@ -92,6 +92,44 @@ func SetupDataEntryRoutes(r *gin.Engine, client *mongo.Client, cfg *config.Confi
 	{
 		jobTitleRoutes.POST("", jobTitleHandler.CreateJobTitleOnce)
 		jobTitleRoutes.GET("", jobTitleHandler.GetJobTitle)
-		// jobTitleRoutes.PATCH("", jobTitleHandler.PatchJobTitle)
+		jobTitleRoutes.PATCH("", jobTitleHandler.UpdateJobTitles)
+		jobTitleRoutes.GET("/history", jobTitleHandler.GetJobTitleHistory)
+	}
+
+	// JOB PREFERENCES routes
+	jobPreferencesHandler := preference.NewJobPreferencesHandler()
+	jobPreferencesRoutes := r.Group("/job-preferences")
+	jobPreferencesRoutes.Use(middleware.AuthMiddleware())
+	{
+		jobPreferencesRoutes.PUT("", jobPreferencesHandler.PutJobPreferences)
+		jobPreferencesRoutes.GET("", jobPreferencesHandler.GetJobPreferences)
+	}
+
+	// PROJECTS AND TOOLS routes
+	projectHandler := preference.NewProjectHandler()
+	projectRoutes := r.Group("/projects")
+	projectRoutes.Use(middleware.AuthMiddleware())
+	{
+		projectRoutes.POST("", projectHandler.CreateProject)
+		projectRoutes.GET("", projectHandler.GetProjects)
+		projectRoutes.PUT("/:id", projectHandler.UpdateProject)
+		projectRoutes.DELETE("/:id", projectHandler.DeleteProject)
+	}
+	toolRoutes := r.Group("/tools")
+	toolRoutes.Use(middleware.AuthMiddleware())
+	{
+		toolRoutes.GET("", projectHandler.GetTools)
+		toolRoutes.PUT("", projectHandler.UpdateTools)
+	}
+
+	// PERSONAS routes
+	personaHandler := preference.NewPersonaHandler()
+	personaRoutes := r.Group("/personas")
+	personaRoutes.Use(middleware.AuthMiddleware())
+	{
+		personaRoutes.POST("", personaHandler.CreatePersona)
+		personaRoutes.GET("", personaHandler.GetPersonas)
+		personaRoutes.PUT("/:id", personaHandler.UpdatePersona)
+		personaRoutes.DELETE("/:id", personaHandler.DeletePersona)
 	}
 }
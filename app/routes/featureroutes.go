@@ -5,7 +5,10 @@ import (
 	"RAAS/core/middlewares"
 	"RAAS/internal/handlers/features/generation"
 	"RAAS/internal/handlers/features/jobs"
+	"RAAS/internal/handlers/features/onboarding"
+	"RAAS/internal/handlers/features/skills"
 	"RAAS/internal/handlers/features/user"
+	"RAAS/internal/models"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -18,12 +21,37 @@ func SetupFeatureRoutes(r *gin.Engine, client *mongo.Client, cfg *config.Config)
 	// Auth Middleware + Pagination helpers
 	auth := middleware.AuthMiddleware()
 	paginate := middleware.PaginationMiddleware
+	adminOnly := middleware.RequireRole(models.RoleAdmin)
 
 	// === USER ===
 
 	seekerProfileHandler := user.NewSeekerProfileHandler()
+	profilePortabilityHandler := user.NewProfilePortabilityHandler()
+	profilePhotoHandler := user.NewProfilePhotoHandler()
+	profileShareHandler := user.NewProfileShareHandler()
 	r.Group("/profile", auth).
-		GET("", seekerProfileHandler.GetSeekerProfile)
+		GET("", seekerProfileHandler.GetSeekerProfile).
+		PUT("", seekerProfileHandler.UpdateSeekerProfile).
+		GET("/export", profilePortabilityHandler.ExportProfile).
+		POST("/import", profilePortabilityHandler.ImportProfile).
+		PUT("/photo", profilePhotoHandler.UploadProfilePhoto).
+		DELETE("/photo", profilePhotoHandler.DeleteProfilePhoto).
+		GET("/visibility", profileShareHandler.GetVisibility).
+		PUT("/visibility", profileShareHandler.UpdateVisibility).
+		POST("/shares", profileShareHandler.CreateShare).
+		GET("/shares", profileShareHandler.GetShares).
+		DELETE("/shares/:slug", profileShareHandler.RevokeShare)
+
+	dataExportHandler := user.NewDataExportHandler()
+	accountHandler := user.NewAccountHandler()
+	r.Group("/account", auth).
+		DELETE("", accountHandler.DeleteAccount).
+		POST("/deletion/cancel", accountHandler.CancelAccountDeletion).
+		POST("/data-export", dataExportHandler.RequestDataExport).
+		GET("/data-exports", dataExportHandler.GetDataExports)
+
+	// Public profile, no authentication
+	r.GET("/p/:slug", profileShareHandler.GetPublicProfile)
 
 	savedJobsHandler := user.NewSavedJobsHandler()
 	r.Group("/saved-jobs", auth, paginate).
@@ -37,13 +65,47 @@ func SetupFeatureRoutes(r *gin.Engine, client *mongo.Client, cfg *config.Config)
 
 	// === JOBS ===
 
+	hiddenJobsHandler := user.NewHiddenJobsHandler()
 	r.Group("/api/jobs", auth, paginate).
-		GET("", jobs.JobRetrievalHandler)
+		GET("", jobs.JobRetrievalHandler).
+		GET("/search", jobs.JobSearchHandler).
+		GET("/:job_id", jobs.JobDetailHandler).
+		POST("/:job_id/hide", hiddenJobsHandler.HideJob).
+		DELETE("/:job_id/hide", hiddenJobsHandler.UnhideJob)
+
+	// Scrapers authenticate with an API key instead of a user token
+	ingestHandler := jobs.NewIngestHandler()
+	r.Group("/api/ingest", middleware.APIKeyMiddleware(cfg.Project.IngestAPIKeys)).
+		POST("/jobs", ingestHandler.IngestJobs)
 
 	linkProviderHandler := jobs.NewLinkProviderHandler()
 	r.Group("/provide-link", auth).
 		POST("", linkProviderHandler.PostAndGetLink)
 
+	// === SKILLS ===
+
+	skillsHandler := skills.NewSkillsHandler()
+	r.Group("/skills", auth).
+		GET("/autocomplete", skillsHandler.Autocomplete)
+
+	// === ADMIN ===
+
+	adminRoutes := r.Group("/admin", auth, adminOnly)
+	adminRoutes.POST("/skills/merge", skillsHandler.MergeSynonyms)
+
+	onboardingHandler := onboarding.NewOnboardingHandler()
+	adminRoutes.GET("/onboarding/steps", onboardingHandler.GetSteps)
+	adminRoutes.PUT("/onboarding/steps", onboardingHandler.ReplaceSteps)
+
+	dedupeHandler := jobs.NewDedupeHandler()
+	adminRoutes.POST("/jobs/dedupe", dedupeHandler.DedupeJobs)
+
+	salaryHandler := jobs.NewSalaryHandler()
+	adminRoutes.POST("/jobs/salaries", salaryHandler.RefreshSalaries)
+
+	jobLifecycleHandler := jobs.NewJobLifecycleHandler()
+	adminRoutes.PUT("/jobs/:job_id/status", jobLifecycleHandler.UpdateJobStatus)
+
 	// === GENERATION ===
 
 	coverLetterHandler := generation.NewCoverLetterHandler()
@@ -23,8 +23,9 @@ func SetupRoutes(r *gin.Engine, client *mongo.Client, cfg *config.Config) {
 
 	corsConfig := cors.Config{
 		AllowOrigins:  origins,
-		AllowMethods:  []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:  []string{"Content-Type", "Content-Length", "Accept-Encoding", "Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With"},
+		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:  []string{"Content-Type", "Content-Length", "Accept-Encoding", "Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With", "If-Match"},
+		ExposeHeaders: []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge: 12 * time.Hour,
 	}
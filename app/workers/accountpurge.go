@@ -0,0 +1,98 @@
+package workers
+
+import (
+	"RAAS/core/config"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+	"RAAS/utils"
+
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AccountPurgeWorker deletes the accounts whose deletion grace period is over: all user
+// documents, the blobs they reference, and finally the auth user. A confirmation email follows.
+type AccountPurgeWorker struct {
+	DB         *mongo.Database
+	Interval   time.Duration
+	DeleteBlob func(ctx context.Context, fileURL string) error
+	SendEmail  func(to, subject, body string) error
+}
+
+// NewAccountPurgeWorker builds the worker from the project config, deleting blobs in Azure Blob Storage
+func NewAccountPurgeWorker(db *mongo.Database, cfg *config.Config) *AccountPurgeWorker {
+	return &AccountPurgeWorker{
+		DB:       db,
+		Interval: time.Duration(cfg.Project.AccountPurgeIntervalMinutes) * time.Minute,
+		DeleteBlob: func(ctx context.Context, fileURL string) error {
+			return repository.NewMediaUploadHandler(repository.GetBlobServiceClient()).DeleteBlob(ctx, fileURL)
+		},
+		SendEmail: func(to, subject, body string) error {
+			return utils.SendEmail(utils.GetEmailConfig(), to, subject, body)
+		},
+	}
+}
+
+// Run purges due accounts right away and then every Interval until ctx is done
+func (w *AccountPurgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if purged, err := w.RunOnce(ctx); err != nil {
+			log.Printf("❌ Account purge run failed: %v", err)
+		} else if purged > 0 {
+			log.Printf("✅ Purged %d deleted accounts", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce purges every account whose deletion is due and returns how many were removed
+func (w *AccountPurgeWorker) RunOnce(ctx context.Context) (int, error) {
+	cursor, err := w.DB.Collection("auth_users").Find(ctx, bson.M{"deletion_scheduled_for": bson.M{"$lte": time.Now()}})
+	if err != nil {
+		return 0, err
+	}
+	var due []models.AuthUser
+	if err := cursor.All(ctx, &due); err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, authUser := range due {
+		if err := repository.PurgeUserData(ctx, w.DB, authUser.AuthUserID, w.DeleteBlob); err != nil {
+			log.Printf("❌ Failed to purge auth_user_id: %s, Error: %v", authUser.AuthUserID, err)
+			continue
+		}
+		purged++
+
+		if err := w.SendEmail(authUser.Email, "Your account has been deleted", accountDeletedBody()); err != nil {
+			log.Printf("Failed to send account deletion confirmation for auth_user_id: %s, Error: %v", authUser.AuthUserID, err)
+		}
+	}
+	return purged, nil
+}
+
+func accountDeletedBody() string {
+	return `
+		<html>
+		<body style="font-family: Arial, sans-serif; color: #333;">
+			<div style="max-width: 600px; margin: auto; padding: 20px;">
+				<h2>Your account has been deleted</h2>
+				<p>As requested, your account and all associated data, including your profile, applications, generated documents and uploaded files, have been permanently deleted.</p>
+				<p>You are welcome to sign up again at any time.</p>
+				<p>Cheers,<br><strong>The Team</strong></p>
+			</div>
+		</body>
+		</html>
+		`
+}
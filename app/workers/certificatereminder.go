@@ -0,0 +1,160 @@
+package workers
+
+import (
+	"RAAS/core/config"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+	"RAAS/utils"
+
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CertificateReminderWorker emails seekers whose certificates expire within ReminderDays.
+// Each certificate is reminded once; the entry is stamped with "expiry_reminder_sent_at".
+type CertificateReminderWorker struct {
+	DB           *mongo.Database
+	ReminderDays int
+	Interval     time.Duration
+	SendEmail    func(to, subject, body string) error
+}
+
+// NewCertificateReminderWorker builds the worker from the project config, sending through SMTP
+func NewCertificateReminderWorker(db *mongo.Database, cfg *config.Config) *CertificateReminderWorker {
+	return &CertificateReminderWorker{
+		DB:           db,
+		ReminderDays: cfg.Project.CertificateReminderDays,
+		Interval:     time.Duration(cfg.Project.CertificateReminderIntervalMinutes) * time.Minute,
+		SendEmail: func(to, subject, body string) error {
+			return utils.SendEmail(utils.GetEmailConfig(), to, subject, body)
+		},
+	}
+}
+
+// Run checks for expiring certificates right away and then every Interval until ctx is done
+func (w *CertificateReminderWorker) Run(ctx context.Context) {
+	if w.ReminderDays <= 0 {
+		log.Printf("Certificate reminders disabled")
+		return
+	}
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if sent, err := w.RunOnce(ctx); err != nil {
+			log.Printf("❌ Certificate reminder run failed: %v", err)
+		} else if sent > 0 {
+			log.Printf("✅ Sent %d certificate expiry reminders", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends the reminders that are due and returns how many emails went out
+func (w *CertificateReminderWorker) RunOnce(ctx context.Context) (int, error) {
+	now := time.Now()
+	horizon := now.AddDate(0, 0, w.ReminderDays)
+	due := bson.M{
+		"expiry_date":             bson.M{"$gt": now, "$lte": horizon},
+		"expiry_reminder_sent_at": bson.M{"$exists": false},
+	}
+
+	seekers := w.DB.Collection("seekers")
+	cursor, err := seekers.Find(ctx,
+		bson.M{"certificates": bson.M{"$elemMatch": due}},
+		options.Find().SetProjection(bson.M{"auth_user_id": 1, "certificates": 1}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	sent := 0
+	for cursor.Next(ctx) {
+		var seeker models.Seeker
+		if err := cursor.Decode(&seeker); err != nil {
+			log.Printf("Error decoding seeker for certificate reminders: %v", err)
+			continue
+		}
+
+		var expiring []bson.M
+		for _, cert := range seeker.Certificates {
+			expiry, ok := repository.CertificateExpiry(cert)
+			if _, reminded := cert["expiry_reminder_sent_at"]; ok && !reminded && expiry.After(now) && !expiry.After(horizon) {
+				expiring = append(expiring, cert)
+			}
+		}
+		if len(expiring) == 0 {
+			continue
+		}
+
+		var authUser models.AuthUser
+		if err := w.DB.Collection("auth_users").FindOne(ctx, bson.M{"auth_user_id": seeker.AuthUserID}).Decode(&authUser); err != nil {
+			log.Printf("No auth user for certificate reminder, auth_user_id: %s, Error: %v", seeker.AuthUserID, err)
+			continue
+		}
+
+		if err := w.SendEmail(authUser.Email, "Your certificates are about to expire", certificateReminderBody(expiring)); err != nil {
+			log.Printf("Failed to send certificate reminder to auth_user_id: %s, Error: %v", seeker.AuthUserID, err)
+			continue
+		}
+		sent++
+
+		// Stamp each reminded certificate so the next run skips it
+		for _, cert := range expiring {
+			_, err := seekers.UpdateOne(ctx,
+				bson.M{"auth_user_id": seeker.AuthUserID},
+				bson.M{"$set": bson.M{"certificates.$[cert].expiry_reminder_sent_at": now}},
+				options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{
+					"cert.certificate_name":        cert["certificate_name"],
+					"cert.expiry_date":             cert["expiry_date"],
+					"cert.expiry_reminder_sent_at": bson.M{"$exists": false},
+				}}}),
+			)
+			if err != nil {
+				log.Printf("Failed to mark certificate reminder for auth_user_id: %s, Error: %v", seeker.AuthUserID, err)
+			}
+		}
+	}
+	return sent, cursor.Err()
+}
+
+func certificateReminderBody(certificates []bson.M) string {
+	var rows strings.Builder
+	for _, cert := range certificates {
+		name, _ := cert["certificate_name"].(string)
+		issuer, _ := cert["issuer"].(string)
+		if issuer != "" {
+			name += " (" + issuer + ")"
+		}
+		fmt.Fprintf(&rows, "<li><strong>%s</strong> expires on %s</li>",
+			html.EscapeString(name), repository.FormatStoredDate(cert["expiry_date"]))
+	}
+
+	return fmt.Sprintf(`
+		<html>
+		<body style="font-family: Arial, sans-serif; color: #333;">
+			<div style="max-width: 600px; margin: auto; padding: 20px;">
+				<h2>Certificates expiring soon</h2>
+				<p>The following certificates on your profile will expire soon:</p>
+				<ul>%s</ul>
+				<p>Renew them and upload the new certificate to keep your profile up to date. Expired certificates are left out of generated CVs.</p>
+				<p>Cheers,<br><strong>The Team</strong></p>
+			</div>
+		</body>
+		</html>
+		`, rows.String())
+}
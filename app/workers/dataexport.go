@@ -0,0 +1,209 @@
+package workers
+
+import (
+	"RAAS/core/config"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+	"RAAS/utils"
+
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// A running export older than this is assumed to belong to a crashed instance and is picked up again
+const staleDataExport = 30 * time.Minute
+
+// DataExportWorker builds the requested data export archives, stores them in a private
+// container and emails the user a link that expires after LinkTTL. Archives are deleted
+// once their link has expired.
+type DataExportWorker struct {
+	DB         *mongo.Database
+	Container  string
+	LinkTTL    time.Duration
+	Interval   time.Duration
+	Download   func(ctx context.Context, fileURL string) ([]byte, error)
+	Upload     func(ctx context.Context, containerName, blobName string, data []byte) (string, error)
+	SignURL    func(containerName, blobName string, expiresAt time.Time) (string, error)
+	DeleteBlob func(ctx context.Context, fileURL string) error
+	SendEmail  func(to, subject, body string) error
+}
+
+// NewDataExportWorker builds the worker from the project config, storing archives in Azure Blob Storage
+func NewDataExportWorker(db *mongo.Database, cfg *config.Config) *DataExportWorker {
+	return &DataExportWorker{
+		DB:        db,
+		Container: cfg.Project.DataExportContainer,
+		LinkTTL:   time.Duration(cfg.Project.DataExportLinkHours) * time.Hour,
+		Interval:  time.Minute,
+		Download: func(ctx context.Context, fileURL string) ([]byte, error) {
+			return repository.NewMediaUploadHandler(repository.GetBlobServiceClient()).DownloadBlob(ctx, fileURL)
+		},
+		Upload: func(ctx context.Context, containerName, blobName string, data []byte) (string, error) {
+			return repository.NewMediaUploadHandler(repository.GetBlobServiceClient()).UploadBlob(ctx, containerName, blobName, data)
+		},
+		SignURL: repository.SignedBlobURL,
+		DeleteBlob: func(ctx context.Context, fileURL string) error {
+			return repository.NewMediaUploadHandler(repository.GetBlobServiceClient()).DeleteBlob(ctx, fileURL)
+		},
+		SendEmail: func(to, subject, body string) error {
+			return utils.SendEmail(utils.GetEmailConfig(), to, subject, body)
+		},
+	}
+}
+
+// Run processes pending exports right away and then every Interval until ctx is done
+func (w *DataExportWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if done, err := w.RunOnce(ctx); err != nil {
+			log.Printf("❌ Data export run failed: %v", err)
+		} else if done > 0 {
+			log.Printf("✅ Completed %d data exports", done)
+		}
+		if expired, err := w.DeleteExpired(ctx, time.Now()); err != nil {
+			log.Printf("❌ Deleting expired data exports failed: %v", err)
+		} else if expired > 0 {
+			log.Printf("✅ Deleted %d expired data exports", expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce works through the pending exports one at a time and returns how many completed
+func (w *DataExportWorker) RunOnce(ctx context.Context) (int, error) {
+	exports := w.DB.Collection("data_exports")
+	done := 0
+	for {
+		now := time.Now()
+		var export models.DataExport
+		err := exports.FindOneAndUpdate(ctx,
+			bson.M{"$or": []bson.M{
+				{"status": models.DataExportPending},
+				{"status": models.DataExportRunning, "started_at": bson.M{"$lt": now.Add(-staleDataExport)}},
+			}},
+			bson.M{"$set": bson.M{"status": models.DataExportRunning, "started_at": now}},
+			options.FindOneAndUpdate().SetSort(bson.D{{Key: "requested_at", Value: 1}}).SetReturnDocument(options.After),
+		).Decode(&export)
+		if err == mongo.ErrNoDocuments {
+			return done, nil
+		}
+		if err != nil {
+			return done, err
+		}
+
+		if err := w.process(ctx, export); err != nil {
+			log.Printf("❌ Data export %s for auth_user_id: %s failed: %v", export.ID.Hex(), export.AuthUserID, err)
+			if _, err := exports.UpdateOne(ctx, bson.M{"_id": export.ID}, bson.M{"$set": bson.M{
+				"status":       models.DataExportFailed,
+				"error":        "The export could not be created, please request a new one",
+				"completed_at": time.Now(),
+			}}); err != nil {
+				log.Printf("Failed to mark data export %s as failed: %v", export.ID.Hex(), err)
+			}
+			continue
+		}
+		done++
+	}
+}
+
+// DeleteExpired deletes the archives whose link expired before now and marks their exports expired.
+// An archive that cannot be deleted is kept ready and retried on the next run.
+func (w *DataExportWorker) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	exports := w.DB.Collection("data_exports")
+	cursor, err := exports.Find(ctx, bson.M{"status": models.DataExportReady, "expires_at": bson.M{"$lt": now}})
+	if err != nil {
+		return 0, err
+	}
+	var expired []models.DataExport
+	if err := cursor.All(ctx, &expired); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, export := range expired {
+		if export.BlobURL != "" {
+			if err := w.DeleteBlob(ctx, export.BlobURL); err != nil {
+				log.Printf("Failed to delete data export %s: %v", export.ID.Hex(), err)
+				continue
+			}
+		}
+		if _, err := exports.UpdateOne(ctx,
+			bson.M{"_id": export.ID, "status": models.DataExportReady},
+			bson.M{"$set": bson.M{"status": models.DataExportExpired}, "$unset": bson.M{"blob_url": ""}},
+		); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func (w *DataExportWorker) process(ctx context.Context, export models.DataExport) error {
+	var authUser models.AuthUser
+	if err := w.DB.Collection("auth_users").FindOne(ctx, bson.M{"auth_user_id": export.AuthUserID}).Decode(&authUser); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	archive, err := repository.BuildDataExportArchive(ctx, w.DB, export.AuthUserID, w.Download, now)
+	if err != nil {
+		return err
+	}
+
+	blobName := fmt.Sprintf("%s/%s.zip", export.AuthUserID, export.ID.Hex())
+	blobURL, err := w.Upload(ctx, w.Container, blobName, archive)
+	if err != nil {
+		return err
+	}
+	expiresAt := now.Add(w.LinkTTL)
+	link, err := w.SignURL(w.Container, blobName, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.DB.Collection("data_exports").UpdateOne(ctx, bson.M{"_id": export.ID}, bson.M{"$set": bson.M{
+		"status":       models.DataExportReady,
+		"completed_at": now,
+		"expires_at":   expiresAt,
+		"blob_url":     blobURL,
+		"size_bytes":   len(archive),
+	}}); err != nil {
+		return err
+	}
+
+	if err := w.SendEmail(authUser.Email, "Your data export is ready", dataExportBody(link, expiresAt)); err != nil {
+		// The archive is ready; the user can still request a fresh link with a new export
+		log.Printf("Failed to send data export email to auth_user_id: %s, Error: %v", export.AuthUserID, err)
+	}
+	return nil
+}
+
+func dataExportBody(link string, expiresAt time.Time) string {
+	return fmt.Sprintf(`
+		<html>
+		<body style="font-family: Arial, sans-serif; color: #333;">
+			<div style="max-width: 600px; margin: auto; padding: 20px;">
+				<h2>Your data export is ready</h2>
+				<p>The archive contains your account, profile and application data as JSON, together with the files you uploaded.</p>
+				<p><a href="%s" style="display: inline-block; padding: 10px 20px; background: #2d6cdf; color: #fff; text-decoration: none; border-radius: 4px;">Download your data</a></p>
+				<p>The link works until %s (UTC). After that, request a new export from your account settings.</p>
+				<p>If you did not request this export, please change your password.</p>
+				<p>Cheers,<br><strong>The Team</strong></p>
+			</div>
+		</body>
+		</html>
+		`, html.EscapeString(link), expiresAt.UTC().Format("2006-01-02 15:04"))
+}
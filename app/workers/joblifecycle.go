@@ -0,0 +1,88 @@
+package workers
+
+import (
+	"RAAS/core/config"
+	"RAAS/internal/handlers/repository"
+
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// jobExpiryBackfillBatch caps the jobs without expires_at that get one per run
+const jobExpiryBackfillBatch = 1000
+
+// JobLifecycleWorker closes the jobs that should leave the feed: it expires jobs past their
+// expires_at, reopens re-delivered ones, and follows job links to mark filled and removed postings.
+// LinkCheck.Client makes the link requests; built with repository.PublicAddress it refuses internal
+// addresses, checks against a local HTTP stub build it with a policy that accepts loopback.
+type JobLifecycleWorker struct {
+	DB        *mongo.Database
+	Interval  time.Duration
+	Expiry    repository.JobExpiryRules
+	LinkCheck repository.JobLinkCheck
+	Now       func() time.Time
+}
+
+// NewJobLifecycleWorker builds the worker from the project config
+func NewJobLifecycleWorker(db *mongo.Database, cfg *config.Config) *JobLifecycleWorker {
+	return &JobLifecycleWorker{
+		DB:       db,
+		Interval: time.Duration(cfg.Project.JobLifecycleIntervalMinutes) * time.Minute,
+		Expiry:   repository.NewJobExpiryRules(cfg.Project.JobExpiryDefaultDays, cfg.Project.JobExpiryDaysBySource),
+		LinkCheck: repository.JobLinkCheck{
+			Client:       repository.NewLinkCheckClient(time.Duration(cfg.Project.JobLinkCheckTimeoutSeconds)*time.Second, repository.PublicAddress),
+			BatchSize:    cfg.Project.JobLinkCheckBatchSize,
+			RecheckAfter: time.Duration(cfg.Project.JobLinkRecheckHours) * time.Hour,
+			MaxFailures:  cfg.Project.JobLinkMaxFailures,
+		},
+		Now: time.Now,
+	}
+}
+
+// Run updates the job statuses right away and then every Interval until ctx is done
+func (w *JobLifecycleWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		if closed, err := w.RunOnce(ctx); err != nil {
+			log.Printf("❌ Job lifecycle run failed: %v", err)
+		} else if closed > 0 {
+			log.Printf("✅ Closed %d jobs", closed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce gives jobs without one their expiry, expires and reopens jobs, then checks one batch
+// of links. It returns the number of jobs that were closed.
+func (w *JobLifecycleWorker) RunOnce(ctx context.Context) (int, error) {
+	now := w.Now()
+	if _, err := repository.AssignJobExpiry(ctx, w.DB, w.Expiry, now, jobExpiryBackfillBatch); err != nil {
+		return 0, err
+	}
+
+	expired, reopened, err := repository.ExpireJobs(ctx, w.DB, now)
+	if err != nil {
+		return 0, err
+	}
+	if reopened > 0 {
+		log.Printf("Reopened %d jobs delivered again with a newer posting date", reopened)
+	}
+
+	checked, removed, err := repository.CheckJobLinks(ctx, w.DB, w.LinkCheck, now)
+	if err != nil {
+		return int(expired), err
+	}
+	if checked > 0 {
+		log.Printf("Checked %d job links, %d postings are closed", checked, removed)
+	}
+	return int(expired) + removed, nil
+}
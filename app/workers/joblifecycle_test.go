@@ -0,0 +1,112 @@
+package workers
+
+import (
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// testDatabase connects to the MongoDB of RAAS_TEST_MONGODB_URI and returns a fresh database
+// that is dropped after the test. Tests that need one are skipped without it.
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("RAAS_TEST_MONGODB_URI")
+	if uri == "" {
+		t.Skip("RAAS_TEST_MONGODB_URI is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	db := client.Database(fmt.Sprintf("raas_test_%d", time.Now().UnixNano()))
+	t.Cleanup(func() {
+		db.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+	return db
+}
+
+func TestJobLifecycleWorkerChecksLinks(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open":
+			w.Write([]byte("<h1>Go Developer</h1>"))
+		case "/filled":
+			w.Write([]byte("This position has been filled"))
+		case "/down":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	expiresAt := now.AddDate(0, 0, 30)
+	jobs := []interface{}{
+		models.Job{JobID: "open", Title: "Go Developer", JobLink: server.URL + "/open", Status: models.JobStatusActive, ExpiresAt: &expiresAt},
+		models.Job{JobID: "filled", Title: "Go Developer", JobLink: server.URL + "/filled", Status: models.JobStatusActive, ExpiresAt: &expiresAt},
+		models.Job{JobID: "gone", Title: "Go Developer", JobLink: server.URL + "/gone", Status: models.JobStatusActive, ExpiresAt: &expiresAt},
+		models.Job{JobID: "down", Title: "Go Developer", JobLink: server.URL + "/down", Status: models.JobStatusActive, ExpiresAt: &expiresAt},
+		models.Job{JobID: "no-link", Title: "Go Developer", Status: models.JobStatusActive, ExpiresAt: &expiresAt},
+	}
+	if _, err := db.Collection("jobs").InsertMany(ctx, jobs); err != nil {
+		t.Fatalf("insert jobs: %v", err)
+	}
+
+	allowLoopback := func(ip netip.Addr) bool { return ip.Unmap().IsLoopback() || repository.PublicAddress(ip) }
+	worker := &JobLifecycleWorker{
+		DB:     db,
+		Expiry: repository.JobExpiryRules{DefaultDays: 30},
+		LinkCheck: repository.JobLinkCheck{
+			Client:       repository.NewLinkCheckClient(time.Second, allowLoopback),
+			BatchSize:    10,
+			RecheckAfter: time.Hour,
+			MaxFailures:  2,
+		},
+		Now: func() time.Time { return now },
+	}
+
+	want := []map[string]string{
+		// First run: the server error is counted, the job without a link is not checked
+		{"open": models.JobStatusActive, "filled": models.JobStatusFilled, "gone": models.JobStatusRemoved,
+			"down": models.JobStatusActive, "no-link": models.JobStatusActive},
+		// Second run, after RecheckAfter: the second failure in a row removes the job
+		{"open": models.JobStatusActive, "down": models.JobStatusRemoved, "no-link": models.JobStatusActive},
+	}
+	for run, statuses := range want {
+		if _, err := worker.RunOnce(ctx); err != nil {
+			t.Fatalf("run %d: %v", run+1, err)
+		}
+		for jobID, status := range statuses {
+			var job models.Job
+			if err := db.Collection("jobs").FindOne(ctx, bson.M{"job_id": jobID}).Decode(&job); err != nil {
+				t.Fatalf("run %d: find %s: %v", run+1, jobID, err)
+			}
+			if got := repository.JobStatus(job); got != status {
+				t.Errorf("run %d: %s is %s, want %s", run+1, jobID, got, status)
+			}
+			if jobID == "no-link" && job.LinkCheckedAt != nil {
+				t.Errorf("run %d: the job without a link was checked", run+1)
+			}
+		}
+		now = now.Add(2 * time.Hour)
+	}
+}
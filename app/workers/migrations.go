@@ -0,0 +1,37 @@
+package workers
+
+import (
+	"RAAS/internal/handlers/repository"
+
+	"context"
+	"log"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Migration brings documents written by an older release up to date. Migrations run on every
+// start and must be idempotent: once applied they find nothing left to change.
+type Migration struct {
+	Name string
+	Run  func(ctx context.Context, db *mongo.Database) (int, error)
+}
+
+// Migrations are run in order at startup
+var Migrations = []Migration{
+	{Name: "work experience ids", Run: repository.AssignWorkExperienceIDs},
+	{Name: "default onboarding steps", Run: repository.AddDefaultOnboardingSteps},
+	{Name: "job title keys", Run: repository.AssignJobTitleKeys},
+	{Name: "job salaries", Run: repository.BackfillJobSalaries},
+}
+
+// RunMigrations applies every migration. A failing migration is logged and retried on the next start,
+// the remaining ones still run.
+func RunMigrations(ctx context.Context, db *mongo.Database) {
+	for _, migration := range Migrations {
+		if changed, err := migration.Run(ctx, db); err != nil {
+			log.Printf("❌ Migration %q failed: %v", migration.Name, err)
+		} else if changed > 0 {
+			log.Printf("✅ Migration %q updated %d documents", migration.Name, changed)
+		}
+	}
+}
@@ -35,6 +35,44 @@ type ProjectConfig struct {
 	RestThrottleClasses          string
 	RestThrottleRatesAnon        string
 	RestThrottleRatesUser        string
+
+	// Data Files
+	SkillsTaxonomyFile           string
+	ProfileCompletenessFile      string
+
+	// Preferred job titles: days a seeker must wait between two changes, per subscription tier
+	JobTitleCooldownFreeDays     int
+	JobTitleCooldownPremiumDays  int
+
+	// Certificate expiry reminders: how many days ahead to warn, and how often the worker runs
+	CertificateReminderDays             int
+	CertificateReminderIntervalMinutes  int
+
+	// Data exports (right of access): blob container of the archives and how long the download link is valid
+	DataExportContainer                 string
+	DataExportLinkHours                 int
+
+	// Account deletion: grace period in which the user can cancel, and how often the purge worker runs
+	AccountDeletionGraceDays            int
+	AccountPurgeIntervalMinutes         int
+
+	// Job ingestion: "client:key" pairs for the scrapers, comma separated, and the batch limit
+	IngestAPIKeys                       string
+	IngestMaxBatchSize                  int
+
+	// Signing key of the pagination cursors, the JWT secret when not set
+	PaginationCursorSecret              string
+
+	// Job expiry: days after posting a job stays listed, by default and per source as "source:days" pairs, comma separated
+	JobExpiryDefaultDays                int
+	JobExpiryDaysBySource               string
+
+	// Job lifecycle worker: how often it runs, and how many links it checks per run, how often and how patiently
+	JobLifecycleIntervalMinutes         int
+	JobLinkCheckBatchSize               int
+	JobLinkRecheckHours                 int
+	JobLinkMaxFailures                  int
+	JobLinkCheckTimeoutSeconds          int
 }
 
 func LoadProjectConfig() (*ProjectConfig, error) {
@@ -67,6 +105,87 @@ func LoadProjectConfig() (*ProjectConfig, error) {
 		RestThrottleClasses:        viper.GetString("REST_FRAMEWORK_DEFAULT_THROTTLE_CLASSES"),
 		RestThrottleRatesAnon:      viper.GetString("REST_FRAMEWORK_DEFAULT_THROTTLE_RATES_ANON"),
 		RestThrottleRatesUser:      viper.GetString("REST_FRAMEWORK_DEFAULT_THROTTLE_RATES_USER"),
+
+		SkillsTaxonomyFile:         viper.GetString("SKILLS_TAXONOMY_FILE"),
+		ProfileCompletenessFile:    viper.GetString("PROFILE_COMPLETENESS_FILE"),
+
+		JobTitleCooldownFreeDays:    viper.GetInt("JOB_TITLE_COOLDOWN_FREE_DAYS"),
+		JobTitleCooldownPremiumDays: viper.GetInt("JOB_TITLE_COOLDOWN_PREMIUM_DAYS"),
+
+		CertificateReminderDays:            viper.GetInt("CERTIFICATE_REMINDER_DAYS"),
+		CertificateReminderIntervalMinutes: viper.GetInt("CERTIFICATE_REMINDER_INTERVAL_MINUTES"),
+
+		DataExportContainer:                viper.GetString("DATA_EXPORT_CONTAINER"),
+		DataExportLinkHours:                viper.GetInt("DATA_EXPORT_LINK_HOURS"),
+
+		AccountDeletionGraceDays:           viper.GetInt("ACCOUNT_DELETION_GRACE_DAYS"),
+		AccountPurgeIntervalMinutes:        viper.GetInt("ACCOUNT_PURGE_INTERVAL_MINUTES"),
+
+		IngestAPIKeys:                      viper.GetString("INGEST_API_KEYS"),
+		IngestMaxBatchSize:                 viper.GetInt("INGEST_MAX_BATCH_SIZE"),
+
+		PaginationCursorSecret:             viper.GetString("PAGINATION_CURSOR_SECRET"),
+
+		JobExpiryDefaultDays:               viper.GetInt("JOB_EXPIRY_DEFAULT_DAYS"),
+		JobExpiryDaysBySource:              viper.GetString("JOB_EXPIRY_DAYS_BY_SOURCE"),
+
+		JobLifecycleIntervalMinutes:        viper.GetInt("JOB_LIFECYCLE_INTERVAL_MINUTES"),
+		JobLinkCheckBatchSize:              viper.GetInt("JOB_LINK_CHECK_BATCH_SIZE"),
+		JobLinkRecheckHours:                viper.GetInt("JOB_LINK_RECHECK_HOURS"),
+		JobLinkMaxFailures:                 viper.GetInt("JOB_LINK_MAX_FAILURES"),
+		JobLinkCheckTimeoutSeconds:         viper.GetInt("JOB_LINK_CHECK_TIMEOUT_SECONDS"),
+	}
+
+	if ProjectConfig.SkillsTaxonomyFile == "" {
+		ProjectConfig.SkillsTaxonomyFile = "./app/data/skills.json"
+	}
+	if !viper.IsSet("JOB_TITLE_COOLDOWN_FREE_DAYS") {
+		ProjectConfig.JobTitleCooldownFreeDays = 30
+	}
+	if !viper.IsSet("JOB_TITLE_COOLDOWN_PREMIUM_DAYS") {
+		ProjectConfig.JobTitleCooldownPremiumDays = 7
+	}
+	if !viper.IsSet("CERTIFICATE_REMINDER_DAYS") {
+		ProjectConfig.CertificateReminderDays = 30
+	}
+	if ProjectConfig.CertificateReminderIntervalMinutes <= 0 {
+		ProjectConfig.CertificateReminderIntervalMinutes = 60
+	}
+	if ProjectConfig.DataExportContainer == "" {
+		ProjectConfig.DataExportContainer = "data-exports"
+	}
+	if ProjectConfig.DataExportLinkHours <= 0 {
+		ProjectConfig.DataExportLinkHours = 72
+	}
+	if !viper.IsSet("ACCOUNT_DELETION_GRACE_DAYS") {
+		ProjectConfig.AccountDeletionGraceDays = 14
+	}
+	if ProjectConfig.AccountPurgeIntervalMinutes <= 0 {
+		ProjectConfig.AccountPurgeIntervalMinutes = 60
+	}
+	if ProjectConfig.IngestMaxBatchSize <= 0 {
+		ProjectConfig.IngestMaxBatchSize = 1000
+	}
+	if ProjectConfig.PaginationCursorSecret == "" {
+		ProjectConfig.PaginationCursorSecret = ProjectConfig.JWTSecretKey
+	}
+	if ProjectConfig.JobExpiryDefaultDays <= 0 {
+		ProjectConfig.JobExpiryDefaultDays = 60
+	}
+	if ProjectConfig.JobLifecycleIntervalMinutes <= 0 {
+		ProjectConfig.JobLifecycleIntervalMinutes = 60
+	}
+	if ProjectConfig.JobLinkCheckBatchSize <= 0 {
+		ProjectConfig.JobLinkCheckBatchSize = 200
+	}
+	if ProjectConfig.JobLinkRecheckHours <= 0 {
+		ProjectConfig.JobLinkRecheckHours = 24
+	}
+	if ProjectConfig.JobLinkMaxFailures <= 0 {
+		ProjectConfig.JobLinkMaxFailures = 3
+	}
+	if ProjectConfig.JobLinkCheckTimeoutSeconds <= 0 {
+		ProjectConfig.JobLinkCheckTimeoutSeconds = 10
 	}
 
 	return ProjectConfig, nil
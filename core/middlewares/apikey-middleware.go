@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyMiddleware authenticates machine clients such as the scrapers. keys holds
+// "client:key" pairs separated by commas; the client name of the matching key is stored
+// as "apiClient" in the context.
+func APIKeyMiddleware(keys string) gin.HandlerFunc {
+	clients := map[string]string{}
+	for _, pair := range strings.Split(keys, ",") {
+		name, key, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if ok && name != "" && key != "" {
+			clients[key] = name
+		}
+	}
+
+	return func(c *gin.Context) {
+		provided := c.GetHeader("X-API-Key")
+		if provided == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "API key missing"})
+			c.Abort()
+			return
+		}
+
+		for key, name := range clients {
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(key)) == 1 {
+				c.Set("apiClient", name)
+				c.Next()
+				return
+			}
+		}
+
+		log.Printf("Error: invalid API key for %s", c.FullPath())
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+		c.Abort()
+	}
+}
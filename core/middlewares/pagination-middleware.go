@@ -1,11 +1,16 @@
 package middleware
 
 import (
+	"RAAS/core/config"
+	"RAAS/internal/handlers/repository"
+
 	"github.com/gin-gonic/gin"
+	"net/http"
 	"strconv"
 )
 
-// PaginationMiddleware handles pagination logic using offset and limit
+// PaginationMiddleware handles pagination logic using offset and limit.
+// A signed cursor (see repository.Page) replaces the offset on the listings that support it.
 func PaginationMiddleware(c *gin.Context) {
 	// Get the "offset" and "limit" query parameters
 	offset := c.DefaultQuery("offset", "0") // Default to 0 if not provided
@@ -28,10 +33,21 @@ func PaginationMiddleware(c *gin.Context) {
 		limitInt = maxLimit // Cap the limit to maxLimit if exceeded
 	}
 
+	// Reject cursors that were tampered with before any handler runs
+	var cursor *repository.PageCursor
+	if token := c.Query("cursor"); token != "" {
+		cursor, err = repository.DecodeCursor(token, config.Cfg.Project.PaginationCursorSecret)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination cursor"})
+			return
+		}
+	}
+
 	// Store pagination information in context
 	c.Set("pagination", gin.H{
 		"offset": offsetInt,
 		"limit":  limitInt,
+		"cursor": cursor,
 	})
 
 	// Proceed to the next handler
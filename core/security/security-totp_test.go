@@ -0,0 +1,56 @@
+package security
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 appendix B test vectors ("12345678901234567890") in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestVerifyTOTPRFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit codes; 6-digit codes are their last six digits
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, v := range vectors {
+		now := time.Unix(v.unix, 0)
+		if !VerifyTOTP(rfc6238Secret, v.code, now) {
+			t.Errorf("VerifyTOTP(%d, %s) = false, want true", v.unix, v.code)
+		}
+		if got := totpCode([]byte("12345678901234567890"), v.unix/totpStep); got != v.code {
+			t.Errorf("totpCode at %d = %s, want %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestVerifyTOTPSkewAndInput(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code := "005924"
+
+	if !VerifyTOTP(rfc6238Secret, code, now.Add(totpStep*time.Second)) {
+		t.Error("code from the previous step rejected")
+	}
+	if VerifyTOTP(rfc6238Secret, code, now.Add(2*totpStep*time.Second)) {
+		t.Error("code from two steps back accepted")
+	}
+	if !VerifyTOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", " "+code+" ", now) {
+		t.Error("lower-case, spaced secret or padded code rejected")
+	}
+	for _, bad := range []string{"", "00592", "0059245", "123456"} {
+		if VerifyTOTP(rfc6238Secret, bad, now) {
+			t.Errorf("VerifyTOTP accepted %q", bad)
+		}
+	}
+	if VerifyTOTP("not base32!", code, now) {
+		t.Error("invalid secret accepted")
+	}
+}
@@ -17,6 +17,15 @@ type LoginInput struct {
     Password string `json:"password" binding:"required"`
 }
 
+// AccountDeletionInput re-confirms the user before the account is scheduled for deletion.
+// Either the password or a current two-factor code is required. Accounts that have neither
+// (social login) send the confirmation code emailed to them by a first request without it.
+type AccountDeletionInput struct {
+    Password         string `json:"password"`
+    TOTPCode         string `json:"totp_code"`
+    ConfirmationCode string `json:"confirmation_code"`
+}
+
 // AuthUserMinimal represents minimal user details for response
 type AuthUserMinimal struct {
     Email         string `json:"email"`
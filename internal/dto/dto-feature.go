@@ -1,14 +1,21 @@
 package dto
 
 import (
+    "RAAS/internal/models"
 
+    "time"
 )
 
 // Job Retrieval
 
 type SalaryRange struct {
-    Min int `json:"min" bson:"min"`
-    Max int `json:"max" bson:"max"`
+    Min        int     `json:"min" bson:"min"`
+    Max        int     `json:"max" bson:"max"`
+    Currency   string  `json:"currency,omitempty" bson:"currency,omitempty"`
+    Period     string  `json:"period,omitempty" bson:"period,omitempty"`     // Unit of the posting, the range is yearly
+    Source     string  `json:"source,omitempty" bson:"source,omitempty"`     // provided, posting or estimate
+    Confidence float64 `json:"confidence,omitempty" bson:"confidence,omitempty"`
+    SampleSize int     `json:"sample_size,omitempty" bson:"sample_size,omitempty"`
 }
 
 type JobDTO struct {
@@ -26,6 +33,87 @@ type JobDTO struct {
     ExpectedSalary SalaryRange  `json:"expected_salary" bson:"expected_salary"` // Expected salary range
     MatchScore     float64      `json:"match_score" bson:"match_score"`         // Match score from 0 to 100
     Description    string       `json:"description" bson:"description"`         // Job description text
+    SourceLinks    []models.SourceLink `json:"source_links,omitempty" bson:"source_links,omitempty"` // Every board the job was posted on
+    Status         string       `json:"status" bson:"status"`                   // active, expired, filled or removed
+    ClosedAt       *time.Time   `json:"closed_at,omitempty" bson:"closed_at,omitempty"`
+}
+
+// JobSearchHitDTO is a job card in the search results with its relevance score
+type JobSearchHitDTO struct {
+    JobDTO
+    Score float64 `json:"score"` // Text relevance, higher is better
+}
+
+// IngestJobRequest is one NDJSON line of POST /api/ingest/jobs. It holds only what the scrapers know
+// about a posting; ids, counters, status and grouping are maintained by the platform.
+type IngestJobRequest struct {
+    JobID             string                      `json:"job_id"`
+    Title             string                      `json:"title"`
+    Company           string                      `json:"company"`
+    Location          string                      `json:"location"`
+    PostedDate        string                      `json:"posted_date"` // YYYY-MM-DD
+    Link              string                      `json:"link"`        // The posting on the job board
+    JobLink           string                      `json:"job_link"`    // The application link
+    Source            string                      `json:"source"`
+    JobDescription    string                      `json:"job_description"`
+    JobType           string                      `json:"job_type"`
+    Skills            string                      `json:"skills"`
+    Industry          string                      `json:"industry"`
+    WorkMode          string                      `json:"work_mode"` // remote, hybrid or onsite
+    RequiredLanguages []IngestLanguageRequirement `json:"required_languages"`
+    Salary            *IngestSalary               `json:"salary"`
+}
+
+// IngestLanguageRequirement is a language a posting asks for, with its CEFR level
+type IngestLanguageRequirement struct {
+    Code  string `json:"code"`
+    Level string `json:"level"`
+}
+
+// IngestSalary is a salary stated by the job board
+type IngestSalary struct {
+    Min      int    `json:"min"`
+    Max      int    `json:"max"`
+    Currency string `json:"currency"`
+    Period   string `json:"period"` // hour, month or year
+}
+
+// JobDetailDTO is the full job on its detail page. JobLink, the application link,
+// is only filled in once the user has selected the job, as with /provide-link.
+type JobDetailDTO struct {
+    JobDTO
+    Link              string                       `json:"link"`
+    JobLink           string                       `json:"job_link,omitempty"`
+    Industry          string                       `json:"industry,omitempty"`
+    WorkMode          string                       `json:"work_mode,omitempty"`
+    Seniority         string                       `json:"seniority,omitempty"`
+    RequiredLanguages []models.LanguageRequirement `json:"required_languages,omitempty"`
+    SelectedCount     int                          `json:"selected_count"`
+    ViewCount         int                          `json:"view_count"`
+    FirstSeenAt       *time.Time                   `json:"first_seen_at,omitempty"`
+    LastSeenAt        *time.Time                   `json:"last_seen_at,omitempty"`
+    ExpiresAt         *time.Time                   `json:"expires_at,omitempty"`
+}
+
+// JobUserStateDTO is what the user has done with a job
+type JobUserStateDTO struct {
+    Saved                bool       `json:"saved"`
+    Selected             bool       `json:"selected"`
+    Hidden               bool       `json:"hidden"`
+    SelectedDate         *time.Time `json:"selected_date,omitempty"`
+    ViewedLink           bool       `json:"viewed_link"`
+    CVGenerated          bool       `json:"cv_generated"`
+    CVURL                string     `json:"cv_url,omitempty"`
+    CoverLetterGenerated bool       `json:"cover_letter_generated"`
+    CoverLetterURL       string     `json:"cover_letter_url,omitempty"`
+    MatchScore           float64    `json:"match_score"`                // Share of the job's skills the user has, 0-100
+}
+
+// JobDetailResponse is the response of GET /api/jobs/:job_id
+type JobDetailResponse struct {
+    Job         JobDetailDTO    `json:"job"`
+    UserState   JobUserStateDTO `json:"user_state"`
+    SimilarJobs []JobDTO        `json:"similar_jobs"`
 }
 
 // JobFilterDTO represents the filter data for job retrieval.
@@ -60,6 +148,8 @@ type SelectedJobResponse struct {
 	CoverLetterGenerated  bool               `json:"cover_letter_generated"`
 	ViewLink              bool               `json:"view_link"`
 	SelectedDate          string             `json:"selected_date"`
+	JobStatus             string             `json:"job_status"` // Status of the job now, removed when it is gone
+	JobClosedAt           *time.Time         `json:"job_closed_at,omitempty"`
 }
 
 type SelectedJobApplicationInput struct {
@@ -74,6 +164,8 @@ type SeekerProfileDTO struct {
     // From PersonalInfo
     FirstName   string  `json:"first_name" bson:"first_name"`
     SecondName  *string `json:"second_name,omitempty" bson:"second_name,omitempty"`
+    ProfilePhotoURL        string            `json:"profile_photo_url,omitempty" bson:"profile_photo_url,omitempty"`
+    ProfilePhotoThumbnails map[string]string `json:"profile_photo_thumbnails,omitempty" bson:"profile_photo_thumbnails,omitempty"`
 
     // From ProfessionalSummary
     Skills []string `json:"skills" bson:"skills"`
@@ -98,7 +190,19 @@ type SeekerProfileDTO struct {
 
     // New: Profile completion percentage
     ProfileCompletion int `json:"profile_completion" bson:"profile_completion"`
+    ProfileCompletionMissing []ProfileCompletionItem `json:"profile_completion_missing" bson:"profile_completion_missing"`
     Languages []string `json:"languages" bson:"languages"` 
+
+    // Profile version, send it back as If-Match on PUT /profile
+    Version int64 `json:"version" bson:"version"`
+}
+
+// ProfileCompletionItem is a profile item that does not count towards the completion score yet
+type ProfileCompletionItem struct {
+    Key    string `json:"key"`
+    Label  string `json:"label"`
+    Weight int    `json:"weight"`
+    Hint   string `json:"hint"`
 }
 
 // LinkResponseDTO represents the response DTO for job application links
@@ -107,3 +211,62 @@ type LinkResponseDTO struct {
     JobLink string `json:"job_link" bson:"job_link"`
     Source  string `json:"source" bson:"source"`
 }
+
+// PublicProfileDTO is the profile shown at /p/:slug. Sections the seeker hid are left empty.
+type PublicProfileDTO struct {
+    Name                    string                   `json:"name"`
+    Headline                string                   `json:"headline,omitempty"`
+    PhotoURL                string                   `json:"photo_url,omitempty"`
+    Summary                 string                   `json:"summary,omitempty"`
+    Skills                  []string                 `json:"skills,omitempty"`
+    TotalExperienceInMonths int                      `json:"total_experience_in_months,omitempty"`
+    WorkExperiences         []PublicWorkExperience   `json:"work_experiences,omitempty"`
+    Education               []PublicEducation        `json:"education,omitempty"`
+    Certificates            []string                 `json:"certificates,omitempty"`
+    Languages               []PublicLanguage         `json:"languages,omitempty"`
+    Contact                 *PublicContact           `json:"contact,omitempty"`
+}
+
+type PublicWorkExperience struct {
+    JobTitle       string `json:"job_title"`
+    CompanyName    string `json:"company_name"`
+    EmploymentType string `json:"employment_type,omitempty"`
+    StartDate      string `json:"start_date"`
+    EndDate        string `json:"end_date,omitempty"` // Empty for the current position
+}
+
+type PublicEducation struct {
+    Degree       string `json:"degree"`
+    Institution  string `json:"institution"`
+    FieldOfStudy string `json:"field_of_study,omitempty"`
+    StartDate    string `json:"start_date"`
+    EndDate      string `json:"end_date,omitempty"`
+}
+
+type PublicLanguage struct {
+    Language    string `json:"language"`
+    Proficiency string `json:"proficiency"`
+}
+
+type PublicContact struct {
+    Email    string `json:"email,omitempty"`
+    Phone    string `json:"phone,omitempty"`
+    LinkedIn string `json:"linkedin,omitempty"`
+}
+
+// ProfileVisibilityRequest updates the public profile sections; omitted fields keep their value
+type ProfileVisibilityRequest struct {
+    Photo          *bool `json:"photo"`
+    Summary        *bool `json:"summary"`
+    Skills         *bool `json:"skills"`
+    WorkExperience *bool `json:"work_experience"`
+    Education      *bool `json:"education"`
+    Certificates   *bool `json:"certificates"`
+    Languages      *bool `json:"languages"`
+    Contact        *bool `json:"contact"`
+}
+
+// ProfileShareRequest creates a share link, valid for ExpiresInDays (default 30)
+type ProfileShareRequest struct {
+    ExpiresInDays int `json:"expires_in_days" binding:"omitempty,min=1,max=365"`
+}
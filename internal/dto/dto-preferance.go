@@ -5,11 +5,24 @@ import (
 	
 	"RAAS/utils"
 
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 )
 
 
+// =======================
+// VALIDATION
+// =======================
+
+// FieldError reports a validation failure against a single input field,
+// e.g. {"field": "work[0].startDate", "message": "must be YYYY-MM-DD"}.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
 // =======================
 // PERSONAL INFO
 // =======================
@@ -55,6 +68,7 @@ type ProfessionalSummaryResponse struct {
 
 // Request payload (for creating or updating)
 type WorkExperienceRequest struct {
+	ID                  string          `json:"id,omitempty" bson:"id,omitempty"` // keeps an existing entry in bulk updates
 	JobTitle            string          `json:"job_title" binding:"required" bson:"job_title"`
 	CompanyName         string          `json:"company_name" binding:"required" bson:"company_name"`
 	EmploymentType      string          `json:"employment_type" binding:"required" bson:"employment_type"`
@@ -106,6 +120,11 @@ type EducationResponse struct {
 type CertificateRequest struct {
 	CertificateName   string  `form:"certificate_name" json:"certificate_name" bson:"certificate_name"`
 	CertificateNumber *string `form:"certificate_number" json:"certificate_number,omitempty" bson:"certificate_number,omitempty"`
+	Issuer            string  `form:"issuer" json:"issuer,omitempty" bson:"issuer,omitempty"`
+	IssueDate         string  `form:"issue_date" json:"issue_date,omitempty" bson:"issue_date,omitempty"`   // YYYY-MM-DD
+	ExpiryDate        string  `form:"expiry_date" json:"expiry_date,omitempty" bson:"expiry_date,omitempty"` // YYYY-MM-DD, empty when it never expires
+	CredentialID      string  `form:"credential_id" json:"credential_id,omitempty" bson:"credential_id,omitempty"`
+	VerificationURL   string  `form:"verification_url" json:"verification_url,omitempty" bson:"verification_url,omitempty" binding:"omitempty,url"`
 }
 
 type CertificateResponse struct {
@@ -114,6 +133,12 @@ type CertificateResponse struct {
 	CertificateName   string              `json:"certificate_name" bson:"certificate_name"`
 	CertificateFile   string              `json:"certificate_file" bson:"certificate_file"`
 	CertificateNumber *string             `json:"certificate_number,omitempty" bson:"certificate_number,omitempty"`
+	Issuer            string              `json:"issuer,omitempty" bson:"issuer,omitempty"`
+	IssueDate         *time.Time          `json:"issue_date,omitempty" bson:"issue_date,omitempty"`
+	ExpiryDate        *time.Time          `json:"expiry_date,omitempty" bson:"expiry_date,omitempty"`
+	CredentialID      string              `json:"credential_id,omitempty" bson:"credential_id,omitempty"`
+	VerificationURL   string              `json:"verification_url,omitempty" bson:"verification_url,omitempty"`
+	Expired           bool                `json:"expired" bson:"-"`
 }
 
 // =======================
@@ -123,7 +148,8 @@ type CertificateResponse struct {
 type LanguageRequest struct {
 	LanguageName     string `json:"language" binding:"required" bson:"language"`
 	CertificateFile  string `json:"certificate_file,omitempty" bson:"certificate_file,omitempty"` // Optional
-	ProficiencyLevel string `json:"proficiency" binding:"required" bson:"proficiency"`
+	ProficiencyLevel string `json:"proficiency" binding:"required" bson:"proficiency"` // CEFR level (A1-C2) or "native"
+	CertificateName  string `form:"certificate_name" json:"certificate_name,omitempty" bson:"certificate_name,omitempty"` // Optional, names an uploaded certificate
 }
 
 
@@ -131,6 +157,7 @@ type LanguageResponse struct {
 	ID               primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	AuthUserID       string              `json:"auth_user_id" bson:"auth_user_id"`
 	LanguageName     string              `json:"language" bson:"language"`
+	LanguageCode     string              `json:"language_code" bson:"language_code"`
 	CertificateFile  string              `json:"certificate_file" bson:"certificate_file,omitempty"`
 	ProficiencyLevel string              `json:"proficiency" bson:"proficiency"`
 }
@@ -151,3 +178,83 @@ type JobTitleResponse struct {
 	SecondaryTitle *string `json:"secondary_title,omitempty" bson:"secondary_title,omitempty"`
 	TertiaryTitle  *string `json:"tertiary_title,omitempty" bson:"tertiary_title,omitempty"`
 }
+
+// =======================
+// PERSONA
+// =======================
+
+// PersonaRequest creates or replaces a persona. Empty overrides fall back to the base profile.
+type PersonaRequest struct {
+	Name                  string   `json:"name" binding:"required"`
+	PrimaryTitle          string   `json:"primary_title" binding:"required"`
+	SecondaryTitle        *string  `json:"secondary_title,omitempty"`
+	TertiaryTitle         *string  `json:"tertiary_title,omitempty"`
+	Summary               *string  `json:"summary,omitempty"`
+	Skills                []string `json:"skills,omitempty"`
+	WorkExperienceIDs     []string `json:"work_experience_ids,omitempty"`
+}
+
+// =======================
+// JOB PREFERENCES
+// =======================
+
+// JobPreferencesRequest replaces the seeker's job-search preferences
+type JobPreferencesRequest struct {
+	Locations         []JobPreferenceLocation `json:"locations" binding:"max=10,dive"`
+	WorkModes         []string                `json:"work_modes" binding:"dive,oneof=remote hybrid onsite"`
+	MinSalary         int                     `json:"min_salary" binding:"min=0"`
+	EmploymentTypes   []string                `json:"employment_types" binding:"dive,oneof=full_time part_time contract temporary internship freelance"`
+	Languages         []string                `json:"languages" binding:"max=10"`
+	Industries        []string                `json:"industries" binding:"max=20"`
+	ExcludedCompanies []string                `json:"excluded_companies" binding:"max=100"`
+}
+
+type JobPreferenceLocation struct {
+	City     string `json:"city" binding:"required"`
+	RadiusKm int    `json:"radius_km" binding:"min=0,max=500"`
+}
+
+// =======================
+// PROJECTS AND TOOLS
+// =======================
+
+// ProjectRequest creates or replaces a portfolio project
+type ProjectRequest struct {
+	Title       string          `json:"title" binding:"required,max=200"`
+	Role        string          `json:"role,omitempty" binding:"max=200"`
+	Description string          `json:"description,omitempty" binding:"max=5000"`
+	TechStack   []string        `json:"tech_stack,omitempty" binding:"max=30"`
+	Links       []string        `json:"links,omitempty" binding:"max=10,dive,url"`
+	StartDate   *utils.DateOnly `json:"start_date,omitempty"`
+	EndDate     *utils.DateOnly `json:"end_date,omitempty"`
+}
+
+// ToolsRequest replaces the seeker's tools list
+type ToolsRequest struct {
+	Tools []string `json:"tools" binding:"max=50"`
+}
+
+// =======================
+// BULK PROFILE UPDATE
+// =======================
+
+// ProfileUpdateRequest saves several profile sections at once. Absent sections are left
+// untouched; list sections replace the stored list. Certificates are not included because
+// they carry file uploads.
+type ProfileUpdateRequest struct {
+	Version             *int64                      `json:"version,omitempty"` // Alternative to the If-Match header
+	PersonalInfo        *PersonalInfoRequest        `json:"personal_info,omitempty"`
+	ProfessionalSummary *ProfessionalSummaryRequest `json:"professional_summary,omitempty"`
+	WorkExperiences     *[]WorkExperienceRequest    `json:"work_experiences,omitempty" binding:"omitempty,dive"`
+	Education           *[]EducationRequest         `json:"education,omitempty" binding:"omitempty,dive"`
+	Languages           *[]LanguageRequest          `json:"languages,omitempty" binding:"omitempty,dive"`
+	JobPreferences      *JobPreferencesRequest      `json:"job_preferences,omitempty"`
+	Projects            *[]ProfileProjectRequest    `json:"projects,omitempty" binding:"omitempty,max=30,dive"`
+	Tools               *[]string                   `json:"tools,omitempty" binding:"omitempty,max=50"`
+}
+
+// ProfileProjectRequest is a project in a bulk update; the id keeps an existing project's identity
+type ProfileProjectRequest struct {
+	ID string `json:"id,omitempty"`
+	ProjectRequest
+}
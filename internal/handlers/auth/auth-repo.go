@@ -5,6 +5,7 @@ import (
 
 	"RAAS/core/config"
 	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
 	"RAAS/internal/models"
 	"RAAS/utils"
 
@@ -123,28 +124,23 @@ func (r *UserRepo) CreateSeeker(input dto.SeekerSignUpInput, hashedPassword stri
 		return fmt.Errorf("failed to create seeker profile: %w", err)
 	}
 
-	// Create Timeline
-	timeline := models.UserEntryTimeline{
-		AuthUserID:                     authUserID,
-		PersonalInfosCompleted:         false,
-		PersonalInfosRequired:          true,
-		ProfessionalSummariesCompleted: false,
-		ProfessionalSummariesRequired:  true,
-
-		WorkExperiencesCompleted:       false,
-		WorkExperiencesRequired:        false,
-		EducationsCompleted:            false,
-		EducationsRequired:             false,
-		CertificatesCompleted:          false,
-		CertificatesRequired:           false,
-		LanguagesCompleted:             false,
-		LanguagesRequired:              false,
-		
-		PreferredJobTitlesCompleted:    false,
-		PreferredJobTitlesRequired:     true,
-		Completed:                      false,
-		CreatedAt:                      time.Now(),
-		UpdatedAt:                      time.Now(),
+	// Create Timeline, the required flags come from the onboarding rules
+	steps, err := repository.LoadOnboardingSteps(ctx, r.DB)
+	if err != nil {
+		return fmt.Errorf("user created but failed to load onboarding steps: %w", err)
+	}
+
+	timeline := bson.M{
+		"auth_user_id": authUserID,
+		"completed":    false,
+		"created_at":   time.Now(),
+		"updated_at":   time.Now(),
+	}
+	for key, required := range repository.TimelineRequiredFlags(steps, seeker.SubscriptionTier) {
+		timeline[key] = required
+	}
+	for _, step := range steps {
+		timeline[step.Key+"_completed"] = false
 	}
 
 	_, err = r.DB.Collection("user_entry_timelines").InsertOne(ctx, timeline)
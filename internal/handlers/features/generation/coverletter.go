@@ -41,6 +41,7 @@ func (h *CoverLetterHandler) PostCoverLetter(c *gin.Context) {
 	// Input: Expect a JobID to retrieve job details
 	var input struct {
 		JobID string `json:"job_id"`
+		PersonaID string `json:"persona_id"`
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
@@ -61,6 +62,13 @@ func (h *CoverLetterHandler) PostCoverLetter(c *gin.Context) {
 		return
 	}
 
+	// The persona, when given, feeds the summary, skills, experiences and designation
+	profile, err := repository.ApplyPersona(seeker, input.PersonaID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Persona not found"})
+		return
+	}
+
 	// Check if daily cover letter quota is exhausted
 	if seeker.DailyGeneratableCoverletter <= 0 {
 		c.JSON(http.StatusTooManyRequests, gin.H{
@@ -79,24 +87,24 @@ func (h *CoverLetterHandler) PostCoverLetter(c *gin.Context) {
 	}
 
 	// Gather details from seeker
-	personalInfo, _ := repository.GetPersonalInfo(&seeker)
-	professionalSummary, _ := repository.GetProfessionalSummary(&seeker)
-	workExperience, _ := repository.GetWorkExperience(&seeker)
-	education, _ := repository.GetEducation(&seeker)
-	certificates, _ := repository.GetCertificates(&seeker)
-	languages, _ := repository.GetLanguages(&seeker)
+	personalInfo, _ := repository.GetPersonalInfo(&profile)
+	professionalSummary, _ := repository.GetProfessionalSummary(&profile)
+	workExperience, _ := repository.GetWorkExperience(&profile)
+	education, _ := repository.GetEducation(&profile)
+	certificates, _ := repository.GetCertificates(&profile)
+	languages, _ := repository.GetLanguages(&profile)
 
 	// Construct the API payload for cover letter generation
 	apiRequestData := map[string]interface{}{
 		"user_details": map[string]interface{}{
 			"name":          fmt.Sprintf("%s %s", personalInfo.FirstName, *personalInfo.SecondName),
-			"designation":   seeker.PrimaryTitle,
+			"designation":   profile.PrimaryTitle,
 			"address":       personalInfo.Address,
 			"contact":       authuser.Phone,
 			"email":         authuser.Email,
-			"portfolio":     "",
+			"portfolio":     repository.PortfolioEntries(&profile),
 			"linkedin":      personalInfo.LinkedInProfile,
-			"tools":         "",
+			"tools":         repository.ToolsSummary(&profile),
 			"skills":        professionalSummary.Skills,
 			"education":     education,
 			"experience_summary": workExperience,
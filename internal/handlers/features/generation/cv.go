@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -33,6 +34,9 @@ func (h *ResumeHandler) PostResume(c *gin.Context) {
 
 	var input struct {
 		JobID string `json:"job_id" binding:"required"`
+		PersonaID string `json:"persona_id"`
+		// Expired certificates are left out unless the seeker asks for them
+		IncludeExpiredCertificates bool `json:"include_expired_certificates"`
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
@@ -51,6 +55,13 @@ func (h *ResumeHandler) PostResume(c *gin.Context) {
 		return
 	}
 
+	// The persona, when given, feeds the summary, skills, experiences and designation
+	profile, err := repository.ApplyPersona(seeker, input.PersonaID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Persona not found"})
+		return
+	}
+
 	var authUser models.AuthUser
 	if err := authUserCollection.FindOne(c, bson.M{"auth_user_id": userID}).Decode(&authUser); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching auth user data"})
@@ -68,12 +79,12 @@ func (h *ResumeHandler) PostResume(c *gin.Context) {
 	}
 
 	// Gather details from seeker
-	personalInfo, _ := repository.GetPersonalInfo(&seeker)
-	professionalSummary, _ := repository.GetProfessionalSummary(&seeker)
-	workExperience, _ := repository.GetWorkExperience(&seeker)
-	educationObjs, _ := repository.GetEducation(&seeker)
-	certificateObjs, _ := repository.GetCertificates(&seeker)
-	languageObjs, _ := repository.GetLanguages(&seeker)
+	personalInfo, _ := repository.GetPersonalInfo(&profile)
+	professionalSummary, _ := repository.GetProfessionalSummary(&profile)
+	workExperience, _ := repository.GetWorkExperience(&profile)
+	educationObjs, _ := repository.GetEducation(&profile)
+	certificateObjs, _ := repository.GetCertificates(&profile)
+	languageObjs, _ := repository.GetLanguages(&profile)
 	
 	// Simplify education
 	education := []string{}
@@ -86,7 +97,7 @@ func (h *ResumeHandler) PostResume(c *gin.Context) {
 
 	// Simplify certifications
 	certifications := []string{}
-	for _, cert := range certificateObjs {
+	for _, cert := range repository.CVCertificates(certificateObjs, input.IncludeExpiredCertificates, time.Now()) {
 		name, _ := cert["certificate_name"].(string)
 		certifications = append(certifications, name)
 	}
@@ -99,17 +110,23 @@ func (h *ResumeHandler) PostResume(c *gin.Context) {
 		languages = append(languages, fmt.Sprintf("%s: %s", langName, proficiency))
 	}
 
+	photo := ""
+	if seeker.ProfilePhoto != nil {
+		photo = seeker.ProfilePhoto.URL
+	}
+
 	// Construct the API payload for CV generation
 	resumeRequest := map[string]interface{}{
 		"user_details": map[string]interface{}{
 			"name":               personalInfo.FirstName + " " + *personalInfo.SecondName,
-			"designation":        seeker.PrimaryTitle,
+			"designation":        profile.PrimaryTitle,
 			"address":            personalInfo.Address,
 			"contact":            authUser.Phone,
 			"email":              authUser.Email,
-			"portfolio":          "",
+			"portfolio":          repository.PortfolioEntries(&profile),
+			"photo":              photo,
 			"linkedin":           personalInfo.LinkedInProfile,
-			"tools":              "", // optional
+			"tools":              repository.ToolsSummary(&profile),
 			"skills":             professionalSummary.Skills,
 			"education":          education,
 			"experience_summary": workExperience,
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"RAAS/internal/handlers/repository"
+
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DedupeHandler groups duplicate job postings
+type DedupeHandler struct{}
+
+func NewDedupeHandler() *DedupeHandler {
+	return &DedupeHandler{}
+}
+
+// DedupeJobs handles POST /admin/jobs/dedupe. It fingerprints every stored job and regroups
+// the duplicates, for jobs stored before ingestion computed fingerprints or after the rules change.
+func (h *DedupeHandler) DedupeJobs(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	canonical, duplicates, err := repository.DedupeAllJobs(ctx, db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to group duplicate jobs"})
+		log.Printf("Error grouping duplicate jobs: %v", err)
+		return
+	}
+
+	log.Printf("✅ Job de-duplication done: %d canonical jobs, %d duplicates", canonical, duplicates)
+	c.JSON(http.StatusOK, gin.H{"canonical_jobs": canonical, "duplicates": duplicates})
+}
@@ -0,0 +1,178 @@
+package jobs
+
+import (
+	"RAAS/core/config"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxIngestBodyBytes caps an NDJSON batch
+const maxIngestBodyBytes = 32 << 20
+
+// ingestRequestTimeout bounds the processing of a batch. A key still processing after it belongs
+// to a request that crashed, and a retry takes it over.
+const ingestRequestTimeout = 60 * time.Second
+
+// IngestHandler receives job batches from the scrapers
+type IngestHandler struct{}
+
+func NewIngestHandler() *IngestHandler {
+	return &IngestHandler{}
+}
+
+// IngestJobs handles POST /api/ingest/jobs with an NDJSON body, one job per line.
+// An Idempotency-Key header makes retries safe: the same key and body return the stored result.
+func (h *IngestHandler) IngestJobs(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+	client := c.GetString("apiClient")
+	requests := db.Collection("ingest_requests")
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIngestBodyBytes))
+	if err != nil {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body too large or unreadable"})
+		return
+	}
+	hash := sha256.Sum256(body)
+	requestHash := hex.EncodeToString(hash[:])
+
+	ctx, cancel := context.WithTimeout(context.Background(), ingestRequestTimeout)
+	defer cancel()
+
+	key := c.GetHeader("Idempotency-Key")
+	var startedAt time.Time
+	if key != "" {
+		startedAt = time.Now()
+		_, err := requests.InsertOne(ctx, models.IngestRequest{
+			Client:      client,
+			Key:         key,
+			RequestHash: requestHash,
+			Status:      models.IngestRequestProcessing,
+			CreatedAt:   startedAt,
+			StartedAt:   startedAt,
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			claimed, claimErr := claimStaleIngestRequest(ctx, requests, client, key, requestHash, startedAt)
+			if claimErr != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up idempotency key"})
+				log.Printf("Failed to take over ingest request %s/%s: %v", client, key, claimErr)
+				return
+			}
+			if !claimed {
+				replayIngestRequest(ctx, c, requests, client, key, requestHash)
+				return
+			}
+			log.Printf("Took over stale ingest request %s/%s", client, key)
+			err = nil
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record idempotency key"})
+			log.Printf("Failed to record ingest request %s/%s: %v", client, key, err)
+			return
+		}
+	}
+
+	expiry := repository.NewJobExpiryRules(config.Cfg.Project.JobExpiryDefaultDays, config.Cfg.Project.JobExpiryDaysBySource)
+	results, err := repository.IngestJobs(ctx, db, body, config.Cfg.Project.IngestMaxBatchSize, expiry, time.Now())
+	if err != nil {
+		// Nothing was stored for this key, so the scraper can retry with it
+		if key != "" {
+			if _, delErr := requests.DeleteOne(ctx, bson.M{"client": client, "key": key, "started_at": startedAt}); delErr != nil {
+				log.Printf("Failed to release ingest request %s/%s: %v", client, key, delErr)
+			}
+		}
+		if errors.Is(err, repository.ErrIngestBatchTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to ingest jobs"})
+		log.Printf("Failed to ingest jobs from %s: %v", client, err)
+		return
+	}
+
+	summary := map[string]int{repository.IngestInserted: 0, repository.IngestUpdated: 0, repository.IngestRejected: 0}
+	for _, result := range results {
+		summary[result.Status]++
+	}
+	response := gin.H{"summary": summary, "results": results}
+
+	if key != "" {
+		raw, err := bson.Marshal(response)
+		if err == nil {
+			now := time.Now()
+			_, err = requests.UpdateOne(ctx, bson.M{"client": client, "key": key, "started_at": startedAt}, bson.M{"$set": bson.M{
+				"status":       models.IngestRequestCompleted,
+				"status_code":  http.StatusOK,
+				"response":     bson.Raw(raw),
+				"completed_at": now,
+			}})
+		}
+		if err != nil {
+			log.Printf("Failed to store ingest result %s/%s: %v", client, key, err)
+		}
+	}
+
+	log.Printf("Ingested jobs from %s: %d inserted, %d updated, %d rejected", client,
+		summary[repository.IngestInserted], summary[repository.IngestUpdated], summary[repository.IngestRejected])
+	c.JSON(http.StatusOK, response)
+}
+
+// claimStaleIngestRequest takes over a key with the same body that is still processing after
+// ingestRequestTimeout, restarting it at now. Records from before started_at existed count as stale.
+// Only one of several concurrent retries gets it.
+func claimStaleIngestRequest(ctx context.Context, requests *mongo.Collection, client, key, requestHash string, now time.Time) (bool, error) {
+	result, err := requests.UpdateOne(ctx,
+		bson.M{
+			"client":       client,
+			"key":          key,
+			"request_hash": requestHash,
+			"status":       models.IngestRequestProcessing,
+			"$or": []bson.M{
+				{"started_at": bson.M{"$lt": now.Add(-ingestRequestTimeout)}},
+				{"started_at": bson.M{"$exists": false}},
+			},
+		},
+		bson.M{"$set": bson.M{"started_at": now}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// replayIngestRequest answers a request whose Idempotency-Key was seen before
+func replayIngestRequest(ctx context.Context, c *gin.Context, requests *mongo.Collection, client, key, requestHash string) {
+	var previous models.IngestRequest
+	if err := requests.FindOne(ctx, bson.M{"client": client, "key": key}).Decode(&previous); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up idempotency key"})
+		log.Printf("Failed to look up ingest request %s/%s: %v", client, key, err)
+		return
+	}
+
+	switch {
+	case previous.RequestHash != requestHash:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request body"})
+	case previous.Status != models.IngestRequestCompleted:
+		c.JSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is still being processed"})
+	default:
+		var response bson.M
+		if err := bson.Unmarshal(previous.Response, &response); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read stored result"})
+			return
+		}
+		c.Header("Idempotent-Replayed", "true")
+		c.JSON(previous.StatusCode, response)
+	}
+}
@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// JobDetailHandler handles GET /api/jobs/:job_id, where the id is a job_id or the numeric id of the cards.
+// It returns the full job, what the user has done with it and similar jobs, and records the view.
+// A duplicate posting shows its canonical job; closed jobs are shown with their status.
+func JobDetailHandler(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+	userID := c.MustGet("userID").(string)
+
+	job, err := repository.FindJob(c, db, c.Param("job_id"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching job data"})
+			log.Printf("Failed to fetch job %s: %v", c.Param("job_id"), err)
+		}
+		return
+	}
+
+	_, skills, err := repository.GetSeekerData(db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching seeker data"})
+		return
+	}
+
+	state, err := repository.FetchJobUserState(c, db, userID, job.JobID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching job state"})
+		log.Printf("Failed to fetch state of job %s for auth_user_id: %s, Error: %v", job.JobID, userID, err)
+		return
+	}
+
+	// Jobs the user hid stay out of the similar jobs
+	hiddenJobIDs, err := repository.FetchHiddenJobIDs(c, db.Collection("hidden_jobs"), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching hidden jobs"})
+		return
+	}
+	similar, err := repository.SimilarJobs(c, db, job, hiddenJobIDs, repository.SimilarJobsLimit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching similar jobs"})
+		log.Printf("Failed to fetch jobs similar to %s: %v", job.JobID, err)
+		return
+	}
+
+	// The job is numbered along with the similar jobs, then taken back out
+	numbered := append([]models.Job{job}, similar...)
+	if err := repository.AssignJobSeqIDs(c, db, numbered); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating job ID"})
+		return
+	}
+	job, similar = numbered[0], numbered[1:]
+
+	// Counting the view is not worth failing the request for
+	if err := repository.RecordJobView(c, db, userID, job.JobID, time.Now()); err != nil {
+		log.Printf("Failed to record view of job %s for auth_user_id: %s, Error: %v", job.JobID, userID, err)
+	}
+
+	response := dto.JobDetailResponse{
+		Job: buildJobDetail(job, skills, state.Selected),
+		UserState: dto.JobUserStateDTO{
+			Saved:                state.Saved,
+			Selected:             state.Selected,
+			Hidden:               state.Hidden,
+			SelectedDate:         state.SelectedDate,
+			ViewedLink:           state.ViewedLink,
+			CVGenerated:          state.CVGenerated,
+			CVURL:                state.CVURL,
+			CoverLetterGenerated: state.CoverLetterGenerated,
+			CoverLetterURL:       state.CoverLetterURL,
+			MatchScore:           repository.SkillMatchScore(job.Skills, skills),
+		},
+		SimilarJobs: []dto.JobDTO{},
+	}
+	for _, similarJob := range similar {
+		response.SimilarJobs = append(response.SimilarJobs, buildJobCard(similarJob, skills))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// buildJobDetail is the job card with every stored field. The application link is
+// left out until the job is selected, as /provide-link does.
+func buildJobDetail(job models.Job, skills []string, selected bool) dto.JobDetailDTO {
+	detail := dto.JobDetailDTO{
+		JobDTO:            buildJobCard(job, skills),
+		Link:              job.Link,
+		Industry:          job.Industry,
+		WorkMode:          job.WorkMode,
+		Seniority:         job.Seniority,
+		RequiredLanguages: job.RequiredLanguages,
+		SelectedCount:     job.SelectedCount,
+		ViewCount:         job.ViewCount,
+		FirstSeenAt:       job.FirstSeenAt,
+		LastSeenAt:        job.LastSeenAt,
+		ExpiresAt:         job.ExpiresAt,
+	}
+	if selected {
+		detail.JobLink = job.JobLink
+	}
+	return detail
+}
@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// JobSearchHandler handles GET /api/jobs/search?q=golang kubernetes&location=Berlin&job_type=&company=&posted=last_week.
+// Hits are ranked by text relevance and come with facet counts by location, job type, company and posting date.
+func JobSearchHandler(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+	userID := c.MustGet("userID").(string)
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter q is required"})
+		return
+	}
+	posted := c.Query("posted")
+	if posted != "" && !repository.ValidPostedBucket(posted) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid posted filter", "details": fmt.Sprintf("use %s, %s, %s or %s",
+			repository.PostedLastDay, repository.PostedLastWeek, repository.PostedLastMonth, repository.PostedOlder)})
+		return
+	}
+
+	_, skills, err := repository.GetSeekerData(db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching seeker data"})
+		return
+	}
+
+	page, err := repository.NewPage(c, repository.JobSearchSort()...)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination cursor", "details": "the cursor belongs to another sort order"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := repository.SearchJobs(ctx, db, repository.JobSearchParams{
+		Query:    query,
+		Location: c.Query("location"),
+		JobType:  c.Query("job_type"),
+		Company:  c.Query("company"),
+		Posted:   posted,
+		Page:     page,
+	}, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error searching jobs"})
+		log.Printf("Error searching jobs for %q: %v", query, err)
+		return
+	}
+
+	pageJobs := make([]models.Job, 0, len(result.Jobs))
+	for _, hit := range result.Jobs {
+		pageJobs = append(pageJobs, hit.Job)
+	}
+	if err := repository.AssignJobSeqIDs(ctx, db, pageJobs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating job ID"})
+		return
+	}
+
+	hits := make([]dto.JobSearchHitDTO, 0, len(result.Jobs))
+	for i, hit := range result.Jobs {
+		hits = append(hits, dto.JobSearchHitDTO{JobDTO: buildJobCard(pageJobs[i], skills), Score: hit.Score})
+	}
+
+	// The envelope's links keep the query and filters
+	response := repository.Paginated(c, hits, result.Info)
+	response["query"] = query
+	response["facets"] = result.Facets
+	c.JSON(http.StatusOK, response)
+}
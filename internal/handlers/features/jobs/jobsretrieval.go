@@ -9,8 +9,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 func JobRetrievalHandler(c *gin.Context) {
 	db := c.MustGet("db").(*mongo.Database)
@@ -23,6 +23,16 @@ func JobRetrievalHandler(c *gin.Context) {
 		return
 	}
 
+	// A persona replaces the titles and skills used for filtering
+	if personaID := c.Query("persona_id"); personaID != "" {
+		seeker, err = repository.ApplyPersona(seeker, personaID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Persona not found"})
+			return
+		}
+		skills = repository.SeekerSkills(seeker)
+	}
+
 	if seeker.PrimaryTitle == "" {
 		c.JSON(http.StatusNoContent, gin.H{"error": "No preferred job title set for user."})
 		return
@@ -47,56 +57,87 @@ func JobRetrievalHandler(c *gin.Context) {
 		appliedJobIDs = []string{}
 	}
 
+	// Saved and hidden jobs are not shown in the feed either
+	savedJobIDs, err := repository.FetchSavedJobIDs(c, db.Collection("saved_jobs"), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching saved jobs"})
+		return
+	}
+	hiddenJobIDs, err := repository.FetchHiddenJobIDs(c, db.Collection("hidden_jobs"), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching hidden jobs"})
+		return
+	}
+	excludedJobIDs := append(append(appliedJobIDs, savedJobIDs...), hiddenJobIDs...)
+
+	// Language levels the seeker can offer, e.g. ?language=German B2&language=en:C1
+	filterOptions := repository.JobFilterOptions{Preferences: seeker.JobPreferences}
+	for _, value := range c.QueryArray("language") {
+		requirement, err := repository.ParseLanguageRequirement(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid language filter", "details": err.Error()})
+			return
+		}
+		filterOptions.LanguageLevels = append(filterOptions.LanguageLevels, requirement)
+	}
+	if err := repository.ParseJobQueryFilters(c.Request.URL.Query(), &filterOptions); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job filter", "details": err.Error()})
+		return
+	}
+
+	sortBy := c.DefaultQuery("sort", repository.JobSortMatch)
+	if !repository.ValidJobSort(sortBy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort", "details": fmt.Sprintf("use %s, %s or %s",
+			repository.JobSortMatch, repository.JobSortPosted, repository.JobSortPopular)})
+		return
+	}
+
 	// Build MongoDB query
-	filter := repository.BuildJobFilter(preferredTitles, appliedJobIDs)
+	filter := repository.BuildJobFilter(preferredTitles, excludedJobIDs, filterOptions)
 
-	// Pagination
-	pagination := c.MustGet("pagination").(gin.H)
-	offset := pagination["offset"].(int)
-	limit := pagination["limit"].(int)
+	// Keyset pagination in the requested order
+	page, err := repository.NewPage(c, repository.JobFeedSort(sortBy)...)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination cursor", "details": "the cursor belongs to another sort order"})
+		return
+	}
 
 	// Query jobs
-	cursor, err := db.Collection("jobs").Find(c, filter, options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)))
+	cursor, err := db.Collection("jobs").Aggregate(c, append(repository.JobFeedPipeline(filter, skills), page.Stages()...))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching job data"})
 		return
 	}
-	defer cursor.Close(c)
+	var docs []bson.Raw
+	if err := cursor.All(c, &docs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching job data"})
+		return
+	}
+	docs, info, err := page.Trim(docs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error building pagination cursor"})
+		return
+	}
 
-	var jobs []dto.JobDTO
-	for cursor.Next(c) {
+	var pageJobs []models.Job
+	for _, doc := range docs {
 		var job models.Job
-		if err := cursor.Decode(&job); err != nil {
+		if err := bson.Unmarshal(doc, &job); err != nil {
 			fmt.Println("Error decoding job:", err)
 			continue
 		}
+		pageJobs = append(pageJobs, job)
+	}
 
-		// Get the next auto-increment ID
-		jobID, err := repository.GetNextSequence(db, "job_id")
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating job ID"})
-			return
-		}
+	// Jobs stored before seq_id existed are numbered in one go
+	if err := repository.AssignJobSeqIDs(c, db, pageJobs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating job ID"})
+		return
+	}
 
-		expectedSalary := dto.SalaryRange(repository.GenerateSalaryRange())
-
-		// Append job with auto-incremented ID
-		jobs = append(jobs, dto.JobDTO{
-			Source:         "seeker",
-			ID:             jobID, // Set the auto-increment ID
-			JobID:          job.JobID,
-			Title:          job.Title,
-			Company:        job.Company,
-			Location:       job.Location,
-			PostedDate:     job.PostedDate,
-			Processed:      job.Processed,
-			JobType:        job.JobType,
-			Skills:         job.Skills,
-			UserSkills:     skills,
-			ExpectedSalary: expectedSalary,
-			MatchScore:     70,
-			Description:    job.JobDescription,
-		})
+	jobs := []dto.JobDTO{}
+	for _, job := range pageJobs {
+		jobs = append(jobs, buildJobCard(job, skills))
 	}
 
 	// Count total jobs
@@ -105,29 +146,34 @@ func JobRetrievalHandler(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error counting job data"})
 		return
 	}
-
-	// Build pagination response
-	nextPage := ""
-	if int64(offset+limit) < totalCount {
-		nextPage = fmt.Sprintf("/api/jobs?offset=%d&limit=%d", offset+limit, limit)
-	}
-	prevPage := ""
-	if offset > 0 {
-		prevPage = fmt.Sprintf("/api/jobs?offset=%d&limit=%d", offset-limit, limit)
-	}
+	info.Total = &totalCount
 
 	// Send response
-	c.JSON(http.StatusOK, gin.H{
-		"pagination": gin.H{
-			"total":    totalCount,
-			"next":     nextPage,
-			"prev":     prevPage,
-			"current":  (offset / limit) + 1,
-			"per_page": limit,
-		},
-		"jobs": jobs,
-	})
+	c.JSON(http.StatusOK, repository.Paginated(c, jobs, info))
 }
 
-
-
+// buildJobCard turns a stored job into the card shown in the feed and the search results
+// The job needs its seq_id, see repository.AssignJobSeqIDs.
+func buildJobCard(job models.Job, skills []string) dto.JobDTO {
+	expectedSalary := dto.SalaryRange(repository.JobExpectedSalary(job))
+
+	return dto.JobDTO{
+		Source:         "seeker",
+		ID:             job.SeqID, // Stable numeric id
+		JobID:          job.JobID,
+		Title:          job.Title,
+		Company:        job.Company,
+		Location:       job.Location,
+		PostedDate:     job.PostedDate,
+		Processed:      job.Processed,
+		JobType:        job.JobType,
+		Skills:         job.Skills,
+		UserSkills:     skills,
+		ExpectedSalary: expectedSalary,
+		MatchScore:     repository.SkillMatchScore(job.Skills, skills),
+		Description:    job.JobDescription,
+		SourceLinks:    job.SourceLinks,
+		Status:         repository.JobStatus(job),
+		ClosedAt:       job.ClosedAt,
+	}
+}
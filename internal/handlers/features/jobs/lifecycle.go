@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"RAAS/core/config"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// JobLifecycleHandler lets admins open and close jobs by hand
+type JobLifecycleHandler struct{}
+
+func NewJobLifecycleHandler() *JobLifecycleHandler {
+	return &JobLifecycleHandler{}
+}
+
+// UpdateJobStatus handles PUT /admin/jobs/:job_id/status with {"status": "filled"}, e.g. when an
+// employer reports a position filled or a job was closed by mistake
+func (h *JobLifecycleHandler) UpdateJobStatus(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input struct {
+		Status string `json:"status" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil || !repository.ValidJobStatus(input.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status", "details": fmt.Sprintf("use %s, %s, %s or %s",
+			models.JobStatusActive, models.JobStatusExpired, models.JobStatusFilled, models.JobStatusRemoved)})
+		return
+	}
+
+	jobID := c.Param("job_id")
+	expiry := repository.NewJobExpiryRules(config.Cfg.Project.JobExpiryDefaultDays, config.Cfg.Project.JobExpiryDaysBySource)
+	if err := repository.SetJobStatus(c, db, jobID, input.Status, expiry, time.Now()); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job status"})
+		log.Printf("Error setting status of job %s to %s: %v", jobID, input.Status, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job status updated", "job_id": jobID, "status": input.Status})
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"RAAS/internal/handlers/repository"
+
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SalaryHandler maintains the salaries of stored jobs
+type SalaryHandler struct{}
+
+func NewSalaryHandler() *SalaryHandler {
+	return &SalaryHandler{}
+}
+
+// RefreshSalaries handles POST /admin/jobs/salaries. It parses the salary of every stored job again
+// and re-estimates the rest, for jobs stored before salaries were parsed or after the rules change.
+func (h *SalaryHandler) RefreshSalaries(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	stated, estimated, err := repository.RefreshJobSalaries(ctx, db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh job salaries"})
+		log.Printf("Error refreshing job salaries: %v", err)
+		return
+	}
+
+	log.Printf("✅ Job salaries refreshed: %d stated, %d estimated", stated, estimated)
+	c.JSON(http.StatusOK, gin.H{"stated": stated, "estimated": estimated})
+}
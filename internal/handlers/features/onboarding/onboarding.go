@@ -0,0 +1,85 @@
+package onboarding
+
+import (
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OnboardingHandler lets admins manage the user entry timeline rules
+type OnboardingHandler struct{}
+
+func NewOnboardingHandler() *OnboardingHandler {
+	return &OnboardingHandler{}
+}
+
+// GetSteps handles GET /admin/onboarding/steps
+func (h *OnboardingHandler) GetSteps(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	steps, err := repository.LoadOnboardingSteps(ctx, db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load onboarding steps"})
+		log.Printf("Error loading onboarding steps: %v", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"steps": steps})
+}
+
+// ReplaceSteps handles PUT /admin/onboarding/steps.
+// The posted list replaces the stored rules, then every existing timeline is backfilled in the background.
+func (h *OnboardingHandler) ReplaceSteps(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input struct {
+		Steps []models.OnboardingStep `json:"steps" binding:"required,dive"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+	if err := repository.ValidateOnboardingSteps(input.Steps); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid onboarding steps", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := repository.ReplaceOnboardingSteps(ctx, db, input.Steps); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save onboarding steps"})
+		log.Printf("Error saving onboarding steps: %v", err)
+		return
+	}
+
+	steps, err := repository.LoadOnboardingSteps(ctx, db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load onboarding steps"})
+		log.Printf("Error loading onboarding steps: %v", err)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		changed, err := repository.BackfillTimelines(ctx, db, steps)
+		if err != nil {
+			log.Printf("❌ Timeline backfill failed after %d changes: %v", changed, err)
+			return
+		}
+		log.Printf("✅ Timeline backfill done: %d timelines changed completion state", changed)
+	}()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Onboarding steps updated, timelines are being backfilled", "steps": steps})
+}
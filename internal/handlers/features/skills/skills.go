@@ -0,0 +1,94 @@
+package skills
+
+import (
+	"RAAS/internal/handlers/repository"
+
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SkillsHandler serves the skills taxonomy
+type SkillsHandler struct{}
+
+func NewSkillsHandler() *SkillsHandler {
+	return &SkillsHandler{}
+}
+
+// Autocomplete handles GET /skills/autocomplete?q=kub&limit=10
+func (h *SkillsHandler) Autocomplete(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	suggestions, err := repository.AutocompleteSkills(ctx, db, c.Query("q"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error loading skills"})
+		log.Printf("Error loading skills taxonomy: %v", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"skills": suggestions})
+}
+
+// MergeSynonyms handles POST /admin/skills/merge.
+// The source skills (and any extra synonyms) are folded into the target skill,
+// then stored seeker and job skills are re-normalized in the background.
+func (h *SkillsHandler) MergeSynonyms(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input struct {
+		TargetID  string   `json:"target_id" binding:"required"`
+		SourceIDs []string `json:"source_ids"`
+		Synonyms  []string `json:"synonyms"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+	if len(input.SourceIDs) == 0 && len(input.Synonyms) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Provide source_ids or synonyms to merge"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	skill, err := repository.MergeSkills(ctx, db, input.TargetID, input.SourceIDs, input.Synonyms)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Target skill not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to merge skills"})
+		}
+		log.Printf("Error merging skills into %s: %v", input.TargetID, err)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		seekers, jobs, err := repository.RenormalizeStoredSkills(ctx, db)
+		if err != nil {
+			log.Printf("❌ Skill re-normalization failed: %v", err)
+			return
+		}
+		log.Printf("✅ Skill re-normalization done: %d seekers, %d jobs updated", seekers, jobs)
+	}()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Skills merged", "skill": skill})
+}
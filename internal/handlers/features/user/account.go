@@ -0,0 +1,232 @@
+package user
+
+import (
+	"RAAS/core/config"
+	"RAAS/core/security"
+	"RAAS/internal/dto"
+	"RAAS/internal/handlers/auth"
+	"RAAS/internal/models"
+	"RAAS/utils"
+
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AccountHandler handles self-service account deletion. Deletion is scheduled after a grace
+// period in which the user can cancel; the account purge worker removes the data afterwards.
+type AccountHandler struct{}
+
+// deletionConfirmationTTL is how long the emailed deletion confirmation code stays valid
+const deletionConfirmationTTL = time.Hour
+
+func NewAccountHandler() *AccountHandler {
+	return &AccountHandler{}
+}
+
+// DeleteAccount handles DELETE /account. The user re-confirms with the password or a two-factor code.
+// Accounts that have neither are emailed a confirmation code by a request without one, and repeat the
+// request with it.
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+	authUsers := db.Collection("auth_users")
+
+	var input dto.AccountDeletionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var authUser models.AuthUser
+	if err := authUsers.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&authUser); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user"})
+		}
+		log.Printf("Error retrieving auth user for deletion, auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	if !hasCredentials(authUser) && input.ConfirmationCode == "" {
+		sendDeletionConfirmation(ctx, c, db, authUser)
+		return
+	}
+
+	if !confirmIdentity(authUser, input, time.Now()) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Re-authentication failed, provide your password, a valid two-factor code or the emailed confirmation code"})
+		return
+	}
+
+	if authUser.DeletionScheduledFor != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"message":                "Account deletion is already scheduled",
+			"deletion_scheduled_for": authUser.DeletionScheduledFor,
+		})
+		return
+	}
+
+	now := time.Now()
+	scheduledFor := now.AddDate(0, 0, config.Cfg.Project.AccountDeletionGraceDays)
+	if _, err := authUsers.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{
+		"$set": bson.M{
+			"deletion_requested_at":  now,
+			"deletion_scheduled_for": scheduledFor,
+			"updated_by":             userID,
+		},
+		"$unset": bson.M{"deletion_confirmation_hash": "", "deletion_confirmation_expiry": ""},
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to schedule account deletion"})
+		log.Printf("Failed to schedule account deletion for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	// Public profile links stop working right away, the rest waits for the grace period
+	if _, err := db.Collection("profile_shares").UpdateMany(ctx,
+		bson.M{"auth_user_id": userID, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": now}},
+	); err != nil {
+		log.Printf("Failed to revoke profile shares for auth_user_id: %s, Error: %v", userID, err)
+	}
+
+	if err := utils.SendEmail(utils.GetEmailConfig(), authUser.Email, "Your account will be deleted", deletionScheduledBody(scheduledFor)); err != nil {
+		log.Printf("Failed to send deletion email to auth_user_id: %s, Error: %v", userID, err)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":                "Account deletion scheduled, you can cancel it until then",
+		"deletion_scheduled_for": scheduledFor,
+	})
+}
+
+// CancelAccountDeletion handles POST /account/deletion/cancel during the grace period
+func (h *AccountHandler) CancelAccountDeletion(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := db.Collection("auth_users").UpdateOne(ctx,
+		bson.M{"auth_user_id": userID, "deletion_scheduled_for": bson.M{"$gt": time.Now()}},
+		bson.M{
+			"$unset": bson.M{"deletion_requested_at": "", "deletion_scheduled_for": ""},
+			"$set":   bson.M{"updated_by": userID},
+		},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel account deletion"})
+		log.Printf("Failed to cancel account deletion for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No pending account deletion"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account deletion cancelled. Revoked profile links stay revoked, create new ones if needed."})
+}
+
+// hasCredentials reports whether the user can re-confirm with a password or a two-factor code
+func hasCredentials(authUser models.AuthUser) bool {
+	return authUser.Password != "" || (authUser.TwoFactorEnabled && authUser.TwoFactorSecret != nil)
+}
+
+// confirmIdentity accepts the account password or, with two-factor authentication on, a current code.
+// Accounts without either accept the emailed confirmation code until it expires.
+func confirmIdentity(authUser models.AuthUser, input dto.AccountDeletionInput, now time.Time) bool {
+	if input.Password != "" && authUser.Password != "" &&
+		bcrypt.CompareHashAndPassword([]byte(authUser.Password), []byte(input.Password)) == nil {
+		return true
+	}
+	if input.TOTPCode != "" && authUser.TwoFactorEnabled && authUser.TwoFactorSecret != nil {
+		return security.VerifyTOTP(*authUser.TwoFactorSecret, input.TOTPCode, now)
+	}
+	if input.ConfirmationCode != "" && !hasCredentials(authUser) &&
+		authUser.DeletionConfirmationHash != "" && authUser.DeletionConfirmationExpiry != nil && now.Before(*authUser.DeletionConfirmationExpiry) {
+		return subtle.ConstantTimeCompare([]byte(hashConfirmationCode(input.ConfirmationCode)), []byte(authUser.DeletionConfirmationHash)) == 1
+	}
+	return false
+}
+
+// sendDeletionConfirmation stores a new confirmation code for the user and emails it
+func sendDeletionConfirmation(ctx context.Context, c *gin.Context, db *mongo.Database, authUser models.AuthUser) {
+	code, err := auth.GenerateResetToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create confirmation code"})
+		return
+	}
+	expiresAt := time.Now().Add(deletionConfirmationTTL)
+	if _, err := db.Collection("auth_users").UpdateOne(ctx, bson.M{"auth_user_id": authUser.AuthUserID}, bson.M{"$set": bson.M{
+		"deletion_confirmation_hash":   hashConfirmationCode(code),
+		"deletion_confirmation_expiry": expiresAt,
+	}}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create confirmation code"})
+		log.Printf("Failed to store deletion confirmation for auth_user_id: %s, Error: %v", authUser.AuthUserID, err)
+		return
+	}
+
+	link := fmt.Sprintf("%s/account/delete?code=%s", config.Cfg.Project.FrontendBaseUrl, code)
+	if err := utils.SendEmail(utils.GetEmailConfig(), authUser.Email, "Confirm your account deletion", deletionConfirmationBody(link, expiresAt)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send confirmation email"})
+		log.Printf("Failed to send deletion confirmation to auth_user_id: %s, Error: %v", authUser.AuthUserID, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message":                 "We sent you an email, repeat the request with its confirmation_code to delete your account",
+		"confirmation_expires_at": expiresAt,
+	})
+}
+
+func hashConfirmationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func deletionConfirmationBody(link string, expiresAt time.Time) string {
+	return fmt.Sprintf(`
+		<html>
+		<body style="font-family: Arial, sans-serif; color: #333;">
+			<div style="max-width: 600px; margin: auto; padding: 20px;">
+				<h2>Confirm your account deletion</h2>
+				<p>We received a request to delete your account. Confirm it with the button below until %s (UTC).</p>
+				<p><a href="%s" style="display: inline-block; padding: 10px 20px; background: #d9534f; color: #fff; text-decoration: none; border-radius: 4px;">Delete my account</a></p>
+				<p>If you did not request this, ignore this email; your account stays as it is.</p>
+				<p>Cheers,<br><strong>The Team</strong></p>
+			</div>
+		</body>
+		</html>
+		`, expiresAt.UTC().Format("2006-01-02 15:04"), html.EscapeString(link))
+}
+
+func deletionScheduledBody(scheduledFor time.Time) string {
+	return fmt.Sprintf(`
+		<html>
+		<body style="font-family: Arial, sans-serif; color: #333;">
+			<div style="max-width: 600px; margin: auto; padding: 20px;">
+				<h2>Your account is scheduled for deletion</h2>
+				<p>We received a request to delete your account. Your profile, applications, generated documents and uploaded files will be removed permanently on <strong>%s</strong> (UTC).</p>
+				<p>Changed your mind? Log in and cancel the deletion in your account settings before then.</p>
+				<p>If you did not request this, log in, cancel the deletion and change your password.</p>
+				<p>Cheers,<br><strong>The Team</strong></p>
+			</div>
+		</body>
+		</html>
+		`, scheduledFor.UTC().Format("2006-01-02 15:04"))
+}
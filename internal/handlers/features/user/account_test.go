@@ -0,0 +1,51 @@
+package user
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/models"
+
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestConfirmIdentity(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withPassword := models.AuthUser{Password: string(hashed)}
+	social := models.AuthUser{DeletionConfirmationHash: hashConfirmationCode("code"), DeletionConfirmationExpiry: &future}
+	expired := models.AuthUser{DeletionConfirmationHash: hashConfirmationCode("code"), DeletionConfirmationExpiry: &past}
+	// A stale code must not bypass the password once one is set
+	passwordAndCode := withPassword
+	passwordAndCode.DeletionConfirmationHash = social.DeletionConfirmationHash
+	passwordAndCode.DeletionConfirmationExpiry = &future
+
+	tests := []struct {
+		name  string
+		user  models.AuthUser
+		input dto.AccountDeletionInput
+		want  bool
+	}{
+		{"password", withPassword, dto.AccountDeletionInput{Password: "secret"}, true},
+		{"wrong password", withPassword, dto.AccountDeletionInput{Password: "nope"}, false},
+		{"confirmation code", social, dto.AccountDeletionInput{ConfirmationCode: "code"}, true},
+		{"wrong confirmation code", social, dto.AccountDeletionInput{ConfirmationCode: "other"}, false},
+		{"expired confirmation code", expired, dto.AccountDeletionInput{ConfirmationCode: "code"}, false},
+		{"code ignored when a password exists", passwordAndCode, dto.AccountDeletionInput{ConfirmationCode: "code"}, false},
+		{"nothing provided", social, dto.AccountDeletionInput{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := confirmIdentity(tt.user, tt.input, now); got != tt.want {
+				t.Errorf("confirmIdentity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DataExportHandler lets a user request an archive of all their data (right of access).
+// The archive is built by the data export worker and delivered by email.
+type DataExportHandler struct{}
+
+func NewDataExportHandler() *DataExportHandler {
+	return &DataExportHandler{}
+}
+
+// RequestDataExport handles POST /account/data-export
+func (h *DataExportHandler) RequestDataExport(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+	exports := db.Collection("data_exports")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// One export at a time; the running one will email the link
+	var inProgress models.DataExport
+	err := exports.FindOne(ctx, bson.M{
+		"auth_user_id": userID,
+		"status":       bson.M{"$in": []string{models.DataExportPending, models.DataExportRunning}},
+	}).Decode(&inProgress)
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "A data export is already in progress", "export": inProgress})
+		return
+	}
+	if err != mongo.ErrNoDocuments {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check data exports"})
+		log.Printf("Error checking data exports for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	export := models.DataExport{
+		AuthUserID:  userID,
+		Status:      models.DataExportPending,
+		RequestedAt: time.Now(),
+	}
+	result, err := exports.InsertOne(ctx, export)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request data export"})
+		log.Printf("Error requesting data export for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	export.ID, _ = result.InsertedID.(primitive.ObjectID)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Data export requested, you will receive an email with the download link",
+		"export":  export,
+	})
+}
+
+// GetDataExports handles GET /account/data-exports, newest first
+func (h *DataExportHandler) GetDataExports(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := db.Collection("data_exports").Find(ctx,
+		bson.M{"auth_user_id": userID},
+		options.Find().SetSort(bson.D{{Key: "requested_at", Value: -1}}).SetLimit(10),
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve data exports"})
+		log.Printf("Error retrieving data exports for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	exports := []models.DataExport{}
+	if err := cursor.All(ctx, &exports); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve data exports"})
+		log.Printf("Error decoding data exports for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"exports": exports})
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// HiddenJobsHandler lets users remove jobs from their feed
+type HiddenJobsHandler struct{}
+
+func NewHiddenJobsHandler() *HiddenJobsHandler {
+	return &HiddenJobsHandler{}
+}
+
+// HideJob handles POST /api/jobs/:job_id/hide. Hiding a duplicate posting hides its canonical job.
+func (h *HiddenJobsHandler) HideJob(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+	userID := c.MustGet("userID").(string)
+
+	job, err := repository.ResolveCanonicalJob(c, db, c.Param("job_id"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching job data"})
+		}
+		return
+	}
+
+	// Hiding twice is not an error, the first hidden_at is kept
+	_, err = db.Collection("hidden_jobs").UpdateOne(c,
+		bson.M{"auth_user_id": userID, "job_id": job.JobID},
+		bson.M{"$setOnInsert": models.HiddenJob{AuthUserID: userID, JobID: job.JobID, HiddenAt: time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not hide job"})
+		log.Printf("Failed to hide job %s for auth_user_id: %s, Error: %v", job.JobID, userID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job hidden", "job_id": job.JobID})
+}
+
+// UnhideJob handles DELETE /api/jobs/:job_id/hide
+func (h *HiddenJobsHandler) UnhideJob(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+	userID := c.MustGet("userID").(string)
+
+	jobID := c.Param("job_id")
+	if job, err := repository.ResolveCanonicalJob(c, db, jobID); err == nil {
+		jobID = job.JobID
+	}
+
+	result, err := db.Collection("hidden_jobs").DeleteOne(c, bson.M{"auth_user_id": userID, "job_id": jobID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not unhide job"})
+		log.Printf("Failed to unhide job %s for auth_user_id: %s, Error: %v", jobID, userID, err)
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job is not hidden"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Job is shown in the feed again", "job_id": jobID})
+}
@@ -0,0 +1,153 @@
+package user
+
+import (
+	"RAAS/core/config"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProfilePhotoHandler manages the seeker's profile photo
+type ProfilePhotoHandler struct{}
+
+func NewProfilePhotoHandler() *ProfilePhotoHandler {
+	return &ProfilePhotoHandler{}
+}
+
+// UploadProfilePhoto handles PUT /profile/photo (multipart, field "file").
+// The image is checked by content, re-encoded without metadata and stored with its thumbnails.
+// The blobs of the previous photo are deleted once the seeker points at the new one.
+func (h *ProfilePhotoHandler) UploadProfilePhoto(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, repository.MaxProfilePhotoBytes+(1<<20))
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file", "details": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, repository.MaxProfilePhotoBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	if len(data) > repository.MaxProfilePhotoBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Profile photo must be at most %d MB", repository.MaxProfilePhotoBytes>>20)})
+		return
+	}
+
+	photo, err := repository.ProcessProfilePhoto(data)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid image", "details": err.Error()})
+		return
+	}
+
+	// A new name per upload so cached URLs of the previous photo are never served
+	version := strconv.FormatInt(time.Now().UnixNano(), 36)
+	container := config.Cfg.Cloud.AzureProfilePicContainer
+	mediaUploadHandler := repository.NewMediaUploadHandler(repository.GetBlobServiceClient())
+
+	photoURL, err := mediaUploadHandler.UploadGeneratedFile(c, container, fmt.Sprintf("%s/%s.jpg", userID, version), photo.Original)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload profile photo"})
+		log.Printf("Failed to upload profile photo for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	profilePhoto := models.ProfilePhoto{URL: photoURL, Thumbnails: map[string]string{}, UpdatedAt: time.Now()}
+	for _, size := range repository.ProfilePhotoSizes {
+		name := fmt.Sprintf("%s/%s_%d.jpg", userID, version, size)
+		thumbnailURL, err := mediaUploadHandler.UploadGeneratedFile(c, container, name, photo.Thumbnails[size])
+		if err != nil {
+			deleteProfilePhotoBlobs(c, mediaUploadHandler, userID, &profilePhoto)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upload profile photo thumbnails"})
+			log.Printf("Failed to upload profile photo thumbnail %d for auth_user_id: %s, Error: %v", size, userID, err)
+			return
+		}
+		profilePhoto.Thumbnails[strconv.Itoa(size)] = thumbnailURL
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	previous, err := replaceProfilePhoto(ctx, db, userID, bson.M{"$set": bson.M{"profile_photo": profilePhoto}})
+	if err != nil {
+		deleteProfilePhotoBlobs(ctx, mediaUploadHandler, userID, &profilePhoto)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save profile photo"})
+		log.Printf("Failed to save profile photo for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	deleteProfilePhotoBlobs(ctx, mediaUploadHandler, userID, previous)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Profile photo updated successfully", "profile_photo": profilePhoto})
+}
+
+// DeleteProfilePhoto handles DELETE /profile/photo
+func (h *ProfilePhotoHandler) DeleteProfilePhoto(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	previous, err := replaceProfilePhoto(ctx, db, userID, bson.M{"$unset": bson.M{"profile_photo": ""}})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove profile photo"})
+		log.Printf("Failed to remove profile photo for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	deleteProfilePhotoBlobs(ctx, repository.NewMediaUploadHandler(repository.GetBlobServiceClient()), userID, previous)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Profile photo removed"})
+}
+
+// replaceProfilePhoto applies the update to the seeker's photo and returns the photo it replaced, if any
+func replaceProfilePhoto(ctx context.Context, db *mongo.Database, userID string, update bson.M) (*models.ProfilePhoto, error) {
+	var before struct {
+		ProfilePhoto *models.ProfilePhoto `bson:"profile_photo"`
+	}
+	err := db.Collection("seekers").FindOneAndUpdate(ctx,
+		bson.M{"auth_user_id": userID},
+		repository.BumpVersion(update),
+		options.FindOneAndUpdate().SetReturnDocument(options.Before).SetProjection(bson.M{"profile_photo": 1}),
+	).Decode(&before)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return before.ProfilePhoto, err
+}
+
+// deleteProfilePhotoBlobs removes a photo and its thumbnails from storage. Failures only leave
+// unreferenced blobs behind, they are logged and not reported to the client.
+func deleteProfilePhotoBlobs(ctx context.Context, mediaUploadHandler *repository.MediaUploadHandler, userID string, photo *models.ProfilePhoto) {
+	if photo == nil {
+		return
+	}
+	urls := []string{photo.URL}
+	for _, thumbnailURL := range photo.Thumbnails {
+		urls = append(urls, thumbnailURL)
+	}
+	for _, url := range urls {
+		if url == "" {
+			continue
+		}
+		if err := mediaUploadHandler.DeleteBlob(ctx, url); err != nil {
+			log.Printf("Failed to delete profile photo blob %s for auth_user_id: %s, Error: %v", url, userID, err)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package user
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const formatJSONResume = "jsonresume"
+
+// ProfilePortabilityHandler imports and exports the seeker profile in interchange formats
+type ProfilePortabilityHandler struct{}
+
+func NewProfilePortabilityHandler() *ProfilePortabilityHandler {
+	return &ProfilePortabilityHandler{}
+}
+
+// ExportProfile handles GET /profile/export?format=jsonresume
+func (h *ProfilePortabilityHandler) ExportProfile(c *gin.Context) {
+	if format := c.DefaultQuery("format", formatJSONResume); format != formatJSONResume {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported export format", "supported": []string{formatJSONResume}})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var seeker models.Seeker
+	if err := db.Collection("seekers").FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Seeker not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving seeker"})
+		}
+		log.Printf("Error retrieving seeker for export, auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	var authUser models.AuthUser
+	if err := db.Collection("auth_users").FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&authUser); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving user"})
+		log.Printf("Error retrieving auth user for export, auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	resume := repository.SeekerToJSONResume(&seeker, &authUser)
+
+	c.Header("Content-Disposition", "attachment; filename=resume.json")
+	c.JSON(http.StatusOK, resume)
+}
+
+// ImportProfile handles POST /profile/import?format=jsonresume.
+// Sections present in the document replace the stored ones; absent sections are kept.
+func (h *ProfilePortabilityHandler) ImportProfile(c *gin.Context) {
+	if format := c.DefaultQuery("format", formatJSONResume); format != formatJSONResume {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported import format", "supported": []string{formatJSONResume}})
+		return
+	}
+
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+	seekersCollection := db.Collection("seekers")
+	authUsersCollection := db.Collection("auth_users")
+	entryTimelineCollection := db.Collection("user_entry_timelines")
+
+	var resume dto.JSONResume
+	if err := c.ShouldBindJSON(&resume); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var seeker models.Seeker
+	if err := seekersCollection.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Seeker not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving seeker"})
+		}
+		log.Printf("Error retrieving seeker for import, auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	var authUser models.AuthUser
+	if err := authUsersCollection.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&authUser); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving user"})
+		return
+	}
+
+	imported, fieldErrs := repository.JSONResumeToSeeker(resume, &seeker)
+	if len(fieldErrs) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid JSON Resume", "details": fieldErrs})
+		return
+	}
+
+	var warnings []string
+	if imported.Email != "" && imported.Email != authUser.Email {
+		// The login email is only changed through the account flow
+		warnings = append(warnings, "basics.email differs from the account email and was not imported")
+	}
+
+	phoneChanged := imported.Phone != "" && imported.Phone != authUser.Phone
+	if phoneChanged {
+		count, err := authUsersCollection.CountDocuments(ctx, bson.M{"phone": imported.Phone, "auth_user_id": bson.M{"$ne": userID}})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check phone number"})
+			return
+		}
+		if count > 0 {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid JSON Resume", "details": []dto.FieldError{
+				{Field: "basics.phone", Message: "is already used by another account"},
+			}})
+			return
+		}
+	}
+
+	seekerUpdate := bson.M{}
+	timelineUpdate := bson.M{}
+	var sections []string
+
+	if imported.PersonalInfo != nil {
+		seekerUpdate["personal_info"] = imported.PersonalInfo
+		// Date of birth is not part of the schema, the step stays open until it is filled
+		if dob, _ := imported.PersonalInfo["date_of_birth"].(string); dob != "" {
+			timelineUpdate["personal_infos_completed"] = true
+		}
+		sections = append(sections, "personal_info")
+	}
+	if imported.ProfessionalSummary != nil {
+		var summary dto.ProfessionalSummaryRequest
+		if err := repository.UnmarshalBsonToStruct(imported.ProfessionalSummary, &summary); err == nil {
+			summary.Skills = repository.NormalizeSkills(ctx, db, summary.Skills)
+			if bsonData, err := repository.MarshalStructToBson(&summary); err == nil {
+				imported.ProfessionalSummary = bsonData
+			}
+		}
+		seekerUpdate["professional_summary"] = imported.ProfessionalSummary
+		timelineUpdate["professional_summaries_completed"] = true
+		sections = append(sections, "professional_summary")
+	}
+	if imported.WorkExperiences != nil {
+		seekerUpdate["work_experiences"] = imported.WorkExperiences
+		timelineUpdate["work_experiences_completed"] = len(imported.WorkExperiences) > 0
+		sections = append(sections, "work_experiences")
+	}
+	if imported.Education != nil {
+		seekerUpdate["education"] = imported.Education
+		timelineUpdate["educations_completed"] = len(imported.Education) > 0
+		sections = append(sections, "education")
+	}
+	if imported.Certificates != nil {
+		seekerUpdate["certificates"] = imported.Certificates
+		timelineUpdate["certificates_completed"] = len(imported.Certificates) > 0
+		sections = append(sections, "certificates")
+	}
+	if imported.Languages != nil {
+		seekerUpdate["languages"] = imported.Languages
+		timelineUpdate["languages_completed"] = len(imported.Languages) > 0
+		sections = append(sections, "languages")
+	}
+	previousTitles := models.JobTitleSet{PrimaryTitle: seeker.PrimaryTitle, SecondaryTitle: seeker.SecondaryTitle, TertiaryTitle: seeker.TertiaryTitle}
+	var importedTitles *models.JobTitleSet
+	if len(imported.JobTitles) > 0 {
+		titles := models.JobTitleSet{
+			PrimaryTitle:   repository.NormalizeJobTitle(ctx, db, imported.JobTitles[0]),
+			SecondaryTitle: repository.NormalizeOptionalJobTitle(ctx, db, optionalTitle(imported.JobTitles, 1)),
+			TertiaryTitle:  repository.NormalizeOptionalJobTitle(ctx, db, optionalTitle(imported.JobTitles, 2)),
+		}
+		inCooldown := seeker.JobTitlesUpdatedAt != nil &&
+			time.Since(*seeker.JobTitlesUpdatedAt) < repository.JobTitleCooldown(seeker.SubscriptionTier)
+
+		switch {
+		case repository.SameJobTitles(previousTitles, titles):
+		case seeker.PrimaryTitle != "" && inCooldown:
+			warnings = append(warnings, "meta.jobTitles was not imported because job titles were changed recently")
+		default:
+			seekerUpdate["primary_title"] = titles.PrimaryTitle
+			seekerUpdate["secondary_title"] = titles.SecondaryTitle
+			seekerUpdate["tertiary_title"] = titles.TertiaryTitle
+			seekerUpdate["job_titles_updated_at"] = time.Now()
+			timelineUpdate["preferred_job_titles_completed"] = true
+			sections = append(sections, "job_titles")
+			importedTitles = &titles
+		}
+	}
+
+	if len(seekerUpdate) == 0 && !phoneChanged {
+		c.JSON(http.StatusOK, gin.H{"message": "Nothing to import", "imported_sections": []string{}, "warnings": warnings})
+		return
+	}
+
+	// The phone lives on the auth user; it is written first and put back when the seeker write fails,
+	// so the two documents never disagree
+	if phoneChanged {
+		if _, err := authUsersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": bson.M{"phone": imported.Phone, "updated_by": userID}}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update phone number"})
+			return
+		}
+		sections = append(sections, "phone")
+	}
+
+	if len(seekerUpdate) > 0 {
+		if _, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(bson.M{"$set": seekerUpdate})); err != nil {
+			if phoneChanged {
+				if _, undoErr := authUsersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": bson.M{"phone": authUser.Phone, "updated_by": authUser.UpdatedBy}}); undoErr != nil {
+					log.Printf("Failed to restore phone number after failed import for auth_user_id: %s, Error: %v", userID, undoErr)
+				}
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save imported profile"})
+			log.Printf("Failed to import profile for auth_user_id: %s, Error: %v", userID, err)
+			return
+		}
+	}
+
+	if importedTitles != nil {
+		if err := repository.RecordJobTitleChange(ctx, db, userID, previousTitles, *importedTitles, time.Now()); err != nil {
+			log.Printf("Failed to record job title history for auth_user_id: %s, Error: %v", userID, err)
+		}
+	}
+
+	if len(timelineUpdate) > 0 {
+		timelineUpdate["updated_at"] = time.Now()
+		if _, err := entryTimelineCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": timelineUpdate}); err != nil {
+			log.Printf("Failed to update timeline after import for auth_user_id: %s, Error: %v", userID, err)
+		}
+	}
+
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":           "Profile imported successfully",
+		"imported_sections": sections,
+		"warnings":          warnings,
+	})
+}
+
+func optionalTitle(titles []string, index int) *string {
+	if index < len(titles) {
+		return &titles[index]
+	}
+	return nil
+}
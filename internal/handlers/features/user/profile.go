@@ -3,6 +3,7 @@ package user
 import (
 
 	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
 	"RAAS/internal/models"
 
 	"context"
@@ -36,7 +37,8 @@ func (h *SeekerProfileHandler) GetSeekerProfile(c *gin.Context) {
 
 	// Find the seeker by auth_user_id
 	var seeker models.Seeker
-	err := seekersCollection.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker)
+	result := seekersCollection.FindOne(ctx, bson.M{"auth_user_id": userID})
+	err := result.Decode(&seeker)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Seeker profile not found"})
@@ -47,6 +49,15 @@ func (h *SeekerProfileHandler) GetSeekerProfile(c *gin.Context) {
 		return
 	}
 
+	// The raw document feeds the completeness rules, which work on field paths
+	var seekerDoc bson.M
+	if err := result.Decode(&seekerDoc); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving seeker profile"})
+		log.Printf("Error decoding seeker profile for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	completeness := repository.CalculateProfileCompleteness(seekerDoc)
+
 	var languageNames []string
 	for _, language := range seeker.Languages {
 		// Ensure 'language' is a map (bson.M), and access the "language" key
@@ -62,7 +73,7 @@ func (h *SeekerProfileHandler) GetSeekerProfile(c *gin.Context) {
 		FirstName:                   dereferenceString(getOptionalField(seeker.PersonalInfo, "first_name")),
 		SecondName:                  getOptionalField(seeker.PersonalInfo, "second_name"),
 		Skills:                      extractSkills(seeker.ProfessionalSummary),
-		TotalExperienceInMonths:     getExperienceInMonths(seeker.WorkExperiences),
+		TotalExperienceInMonths:     repository.TotalExperienceMonths(seeker.WorkExperiences, time.Now()),
 		Certificates:                extractCertificates(seeker.Certificates),
 		PreferredJobTitle:           seeker.PrimaryTitle,
 		SubscriptionTier:            seeker.SubscriptionTier,
@@ -71,13 +82,98 @@ func (h *SeekerProfileHandler) GetSeekerProfile(c *gin.Context) {
 		DailyGeneratableCoverletter: seeker.DailyGeneratableCoverletter,
 		TotalApplications:           seeker.TotalApplications,
 		TotalJobsAvailable:          0, // For now, as you said
-		ProfileCompletion:           calculateProfileCompletion(seeker),
+		ProfileCompletion:           completeness.Score,
+		ProfileCompletionMissing:    completeness.Missing,
 		Languages:                  languageNames, 
+		Version:                     seeker.Version,
 	}
 
+	if seeker.ProfilePhoto != nil {
+		profile.ProfilePhotoURL = seeker.ProfilePhoto.URL
+		profile.ProfilePhotoThumbnails = seeker.ProfilePhoto.Thumbnails
+	}
+
+	c.Header("ETag", repository.ETag(seeker.Version))
 	c.JSON(http.StatusOK, profile)
 }
 
+// UpdateSeekerProfile handles PUT /profile, saving several sections at once.
+// The client sends the version it read (If-Match header or "version" field); the update is
+// rejected with 412 when the profile changed in the meantime, e.g. from another tab.
+func (h *SeekerProfileHandler) UpdateSeekerProfile(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input dto.ProfileUpdateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	version, ok := repository.ParseIfMatch(c.GetHeader("If-Match"))
+	if !ok && input.Version != nil {
+		version, ok = *input.Version, true
+	}
+	if !ok {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "Profile version required, send the ETag of GET /profile as If-Match"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var seeker models.Seeker
+	if err := db.Collection("seekers").FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Seeker profile not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving seeker profile"})
+		}
+		log.Printf("Error retrieving seeker profile for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	if seeker.Version != version {
+		c.Header("ETag", repository.ETag(seeker.Version))
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": repository.ErrVersionConflict.Error(), "version": seeker.Version})
+		return
+	}
+
+	update, fieldErrs := repository.BuildProfileUpdate(ctx, db, &seeker, input, time.Now())
+	if len(fieldErrs) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid profile", "details": fieldErrs})
+		return
+	}
+	if len(update.Set) == 0 {
+		c.Header("ETag", repository.ETag(seeker.Version))
+		c.JSON(http.StatusOK, gin.H{"message": "Nothing to update", "updated_sections": []string{}, "version": seeker.Version})
+		return
+	}
+
+	newVersion, err := repository.ApplyProfileUpdate(ctx, db, userID, version, update)
+	if err == repository.ErrVersionConflict {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save profile"})
+		log.Printf("Failed to apply bulk profile update for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
+	c.Header("ETag", repository.ETag(newVersion))
+	response := gin.H{
+		"message":          "Profile updated successfully",
+		"updated_sections": update.Sections,
+		"version":          newVersion,
+	}
+	if len(update.Warnings) > 0 {
+		response["warnings"] = update.Warnings
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 
 
 func dereferenceString(str *string) string {
@@ -111,69 +207,6 @@ func extractSkills(professionalSummary bson.M) []string {
 	return nil
 }
 
-func getExperienceInMonths(workExperiences []bson.M) int {
-	totalMonths := 0
-	for i, exp := range workExperiences {
-		// Print the entire work experience first
-		log.Printf("[DEBUG] Work experience #%d: %v", i+1, exp)
-
-		if startDate, ok := exp["start_date"].(map[string]interface{}); ok {
-			// Directly extract the Unix timestamp (in milliseconds) from the start_date map
-			startTimeUnixMillis, startOk := startDate["time"].(float64)
-			if !startOk {
-				log.Printf("[WARN] No start time found for experience #%d", i+1)
-				continue // Skip if there's no start time
-			}
-
-			// Convert the Unix timestamp (in milliseconds) to seconds
-			startTimeUnixSecs := time.Unix(int64(startTimeUnixMillis/1000), 0)
-
-			// Log the parsed start time
-			log.Printf("[DEBUG] Start time for experience #%d: %s", i+1, startTimeUnixSecs)
-
-			// Default to current time if there's no end date
-			end := time.Now()
-
-			// Check if end date exists, if it does, use it
-			if endDate, ok := exp["end_date"].(map[string]interface{}); ok && endDate["time"] != nil {
-				endTimeUnixMillis, endOk := endDate["time"].(float64)
-				if endOk {
-					// Convert the Unix timestamp (in milliseconds) to seconds
-					end = time.Unix(int64(endTimeUnixMillis/1000), 0)
-				}
-			}
-
-			// Log the parsed end time
-			log.Printf("[DEBUG] End time for experience #%d: %s", i+1, end)
-
-			// Calculate duration in months
-			years := end.Year() - startTimeUnixSecs.Year()
-			months := int(end.Month()) - int(startTimeUnixSecs.Month())
-
-			durationInMonths := years*12 + months
-			if durationInMonths < 0 {
-				durationInMonths = 0 // just in case
-			}
-
-			// Debugging log
-			log.Printf("[DEBUG] Experience #%d duration: %d months", i+1, durationInMonths)
-
-			// Add to total
-			totalMonths += durationInMonths
-		} else {
-			// Log if start date is missing
-			log.Printf("[WARN] No start date found for experience #%d", i+1)
-		}
-	}
-
-	// Debugging total experience
-	log.Printf("[DEBUG] Total experience across all entries: %d months", totalMonths)
-	return totalMonths
-}
-
-
-
-
 // Helper function to extract certificates
 func extractCertificates(certificates []bson.M) []string {
 	var result []string
@@ -184,50 +217,3 @@ func extractCertificates(certificates []bson.M) []string {
 	}
 	return result
 }
-
-// Helper function to calculate profile completion
-func calculateProfileCompletion(seeker models.Seeker) int {
-	completion := 0
-
-	// Personal Info
-	if seeker.PersonalInfo != nil {
-		if seeker.PersonalInfo["first_name"] != nil {
-			completion += 10
-		}
-		if seeker.PersonalInfo["second_name"] != nil {
-			completion += 10
-		}
-	}
-
-	// Skills
-	if skills := extractSkills(seeker.ProfessionalSummary); len(skills) > 0 {
-		completion += 20
-	}
-
-	// Work Experience
-	if len(seeker.WorkExperiences) > 0 {
-		completion += 20
-	}
-
-	// Certificates
-	if len(seeker.Certificates) > 0 {
-		completion += 20
-	}
-
-	// Preferred Job Title
-	if seeker.PrimaryTitle != "" {
-		completion += 20
-	}
-
-	// Subscription Tier
-	if seeker.SubscriptionTier != "" {
-		completion += 10
-	}
-
-	// Ensure completion is capped at 100
-	if completion > 100 {
-		completion = 100
-	}
-
-	return completion
-}
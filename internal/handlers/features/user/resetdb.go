@@ -51,9 +51,15 @@ func ResetDBHandler(c *gin.Context) {
 	userID := authUser.AuthUserID // Convert UUID to string
 	log.Printf("🔄 Reset triggered for user: %s (ID: %s)", req.Email, userID)
 
-	// Attempt to delete user by string ID
+	// Delete user data from each collection, the auth user last
+	for _, collectionName := range models.UserDataCollections {
+		if collectionName != "auth_users" {
+			deleteUserDataFromCollection(c, db, collectionName, userID)
+		}
+	}
+
 	log.Printf("🔄 Attempting to delete user from auth_users with ID: %s", userID)
-	_, err = db.Collection("auth_users").DeleteOne(c, bson.M{"_id": authUser.AuthUserID})
+	_, err = db.Collection("auth_users").DeleteOne(c, bson.M{"auth_user_id": userID})
 	if err != nil {
 		log.Printf("❌ Failed to delete user from auth_users: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete user"})
@@ -63,25 +69,6 @@ func ResetDBHandler(c *gin.Context) {
 	// Log the result
 	log.Printf("✅ Deleted user with ID: %s", userID)
 
-	// Clean each collection where user data might exist
-	collections := []string{
-		"seekers", 
-		"user_entry_timelines", 
-		"cover_letters", 
-		"cv", 
-		"selected_job_applications",
-		"jobs", 
-		"admins",
-		"match_scores", 
-		"auth_users",
-		"saved_jobs",
-	}
-
-	// Delete user data from each collection
-	for _, collectionName := range collections {
-		deleteUserDataFromCollection(c, db, collectionName, userID)
-	}
-
 	c.JSON(http.StatusOK, gin.H{"message": "User and associated data deleted successfully."})
 }
 
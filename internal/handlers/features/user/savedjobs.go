@@ -5,13 +5,11 @@ import (
 	"RAAS/internal/handlers/repository"
 	"RAAS/internal/models"
 
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // SavedJobsHandler handles saving and retrieving saved jobs
@@ -36,10 +34,20 @@ func (h *SavedJobsHandler) SaveJob(c *gin.Context) {
 		return
 	}
 
+	// Saving a duplicate posting saves its canonical job
+	jobID := payload.JobID
+	if job, err := repository.ResolveCanonicalJob(c, db, payload.JobID); err == nil {
+		if status := repository.JobStatus(job); status != models.JobStatusActive {
+			c.JSON(http.StatusGone, gin.H{"error": "This job is no longer open", "status": status})
+			return
+		}
+		jobID = job.JobID
+	}
+
 	savedJob := models.SavedJob{
 		AuthUserID: userID,
 		Source:     payload.Source,
-		JobID:      payload.JobID,
+		JobID:      jobID,
 	}
 
 	_, err := db.Collection("saved_jobs").InsertOne(c, savedJob)
@@ -59,28 +67,69 @@ func (h *SavedJobsHandler) GetSavedJobs(c *gin.Context) {
 	db := c.MustGet("db").(*mongo.Database)
 	userID := c.MustGet("userID").(string)
 
-	savedJobIDs, err := repository.FetchSavedJobIDs(c, db.Collection("saved_jobs"), userID)
+	savedJobsCollection := db.Collection("saved_jobs")
+	filter := bson.M{"auth_user_id": userID}
+
+	totalCount, err := savedJobsCollection.CountDocuments(c, filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching saved jobs"})
 		return
 	}
 
-	if len(savedJobIDs) == 0 {
+	if totalCount == 0 {
 		c.JSON(http.StatusNoContent, gin.H{"message": "No saved jobs found"})
 		return
 	}
 
-	pagination := c.MustGet("pagination").(gin.H)
-	offset := pagination["offset"].(int)
-	limit := pagination["limit"].(int)
+	// Most recently saved first, the ObjectID carries the time of saving
+	page, err := repository.NewPage(c, repository.SortKey{Field: "_id", Desc: true})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination cursor"})
+		return
+	}
+	cursor, err := savedJobsCollection.Find(c, bson.M{"$and": []bson.M{filter, page.Filter()}}, page.FindOptions())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching saved jobs"})
+		return
+	}
+	var docs []bson.Raw
+	if err := cursor.All(c, &docs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching saved jobs"})
+		return
+	}
+	docs, info, err := page.Trim(docs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error building pagination cursor"})
+		return
+	}
+	info.Total = &totalCount
 
-	filter := bson.M{"job_id": bson.M{"$in": savedJobIDs}}
-	cursor, err := db.Collection("jobs").Find(c, filter, options.Find().SetSkip(int64(offset)).SetLimit(int64(limit)))
+	savedJobIDs := []string{}
+	for _, doc := range docs {
+		var saved models.SavedJob
+		if err := bson.Unmarshal(doc, &saved); err == nil {
+			savedJobIDs = append(savedJobIDs, saved.JobID)
+		}
+	}
+
+	jobCursor, err := db.Collection("jobs").Find(c, bson.M{"job_id": bson.M{"$in": savedJobIDs}})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching job data"})
 		return
 	}
-	defer cursor.Close(c)
+	var savedJobs []models.Job
+	if err := jobCursor.All(c, &savedJobs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching job data"})
+		return
+	}
+	if err := repository.AssignJobSeqIDs(c, db, savedJobs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating job ID"})
+		return
+	}
+	jobsByID := map[string]models.Job{}
+	for _, job := range savedJobs {
+		jobsByID[job.JobID] = job
+	}
 
 	_, skills, err := repository.GetSeekerData(db, userID)
 	if err != nil {
@@ -88,16 +137,18 @@ func (h *SavedJobsHandler) GetSavedJobs(c *gin.Context) {
 		return
 	}
 
-	var jobs []dto.JobDTO
-	for cursor.Next(c) {
-		var job models.Job
-		if err := cursor.Decode(&job); err != nil {
+	// Cards keep the order of the saved jobs page
+	jobs := []dto.JobDTO{}
+	for _, jobID := range savedJobIDs {
+		job, ok := jobsByID[jobID]
+		if !ok {
 			continue
 		}
 
-		expectedSalary := dto.SalaryRange(repository.GenerateSalaryRange())
+		expectedSalary := dto.SalaryRange(repository.JobExpectedSalary(job))
 		jobs = append(jobs, dto.JobDTO{
 			Source:         "saved",
+			ID:             job.SeqID,
 			JobID:          job.JobID,
 			Title:          job.Title,
 			Company:        job.Company,
@@ -110,29 +161,12 @@ func (h *SavedJobsHandler) GetSavedJobs(c *gin.Context) {
 			ExpectedSalary: expectedSalary,
 			MatchScore:     50,
 			Description:    job.JobDescription,
+			Status:         repository.JobStatus(job), // Saved jobs stay listed after they close
+			ClosedAt:       job.ClosedAt,
 		})
 	}
 
-	totalCount := int64(len(savedJobIDs))
-	nextPage := ""
-	if int64(offset+limit) < totalCount {
-		nextPage = fmt.Sprintf("/api/saved-jobs?offset=%d&limit=%d", offset+limit, limit)
-	}
-	prevPage := ""
-	if offset > 0 {
-		prevPage = fmt.Sprintf("/api/saved-jobs?offset=%d&limit=%d", offset-limit, limit)
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"pagination": gin.H{
-			"total":    totalCount,
-			"next":     nextPage,
-			"prev":     prevPage,
-			"current":  (offset / limit) + 1,
-			"per_page": limit,
-		},
-		"jobs": jobs,
-	})
+	c.JSON(http.StatusOK, repository.Paginated(c, jobs, info))
 }
 
 
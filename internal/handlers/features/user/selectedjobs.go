@@ -12,7 +12,6 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // SelectedJobsHandler handles job selection operations
@@ -38,9 +37,20 @@ func (h *SelectedJobsHandler) PostSelectedJob(c *gin.Context) {
 
 	selectedJobsCollection := db.Collection("selected_job_applications")
 
+	// Selecting a duplicate posting selects its canonical job
+	job, err := repository.ResolveCanonicalJob(c, db, input.JobID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if status := repository.JobStatus(job); status != models.JobStatusActive {
+		c.JSON(http.StatusGone, gin.H{"error": "This job is no longer open", "status": status})
+		return
+	}
+
 	// Prevent duplicates
 	var existing models.SelectedJobApplication
-	err := selectedJobsCollection.FindOne(c, bson.M{"auth_user_id": userID, "job_id": input.JobID}).Decode(&existing)
+	err = selectedJobsCollection.FindOne(c, bson.M{"auth_user_id": userID, "job_id": job.JobID}).Decode(&existing)
 	if err != mongo.ErrNoDocuments {
 		c.JSON(http.StatusConflict, gin.H{"error": "You have already selected this job"})
 		return
@@ -53,13 +63,7 @@ func (h *SelectedJobsHandler) PostSelectedJob(c *gin.Context) {
 		return
 	}
 
-	job, err := repository.GetJobByID(db, input.JobID)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
-		return
-	}
-
-	expectedSalary := repository.GenerateSalaryRange()
+	expectedSalary := repository.JobExpectedSalary(job)
 
 	selectedJob := models.SelectedJobApplication{
 		AuthUserID:           userID,
@@ -106,16 +110,18 @@ func (h *SelectedJobsHandler) GetSelectedJobs(c *gin.Context) {
 	// Define the filter to fetch selected jobs for the authenticated user
 	filter := bson.M{"auth_user_id": userID}
 
-	// Access pagination values from context set by middleware
-	pagination := c.MustGet("pagination").(gin.H)
-	offsetInt := pagination["offset"].(int)
-	limitInt := pagination["limit"].(int)
-
-	// Define the pagination options
-	findOptions := options.Find().SetSkip(int64(offsetInt)).SetLimit(int64(limitInt))
+	// Most recently selected first, using the cursor from the middleware
+	page, err := repository.NewPage(c,
+		repository.SortKey{Field: "selected_date", Desc: true},
+		repository.SortKey{Field: "_id", Desc: true},
+	)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination cursor"})
+		return
+	}
 
 	// Query the database to retrieve selected jobs for the authenticated user
-	cursor, err := selectedJobsCollection.Find(c, filter, findOptions)
+	cursor, err := selectedJobsCollection.Find(c, bson.M{"$and": []bson.M{filter, page.Filter()}}, page.FindOptions())
 	if err != nil {
 		fmt.Println("Error fetching selected jobs:", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -123,18 +129,49 @@ func (h *SelectedJobsHandler) GetSelectedJobs(c *gin.Context) {
 		})
 		return
 	}
-	defer cursor.Close(c)
-
-	// Slice to hold the response data
-	var selectedJobs []dto.SelectedJobResponse
+	var docs []bson.Raw
+	if err := cursor.All(c, &docs); err != nil {
+		fmt.Println("Error fetching selected jobs:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Error fetching selected jobs",
+		})
+		return
+	}
+	docs, info, err := page.Trim(docs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error building pagination cursor"})
+		return
+	}
 
-	// Iterate through the cursor and decode the documents
-	for cursor.Next(c) {
+	// Decode the documents of the page
+	var pageJobs []models.SelectedJobApplication
+	var jobIDs []string
+	for _, doc := range docs {
 		var selectedJob models.SelectedJobApplication
-		if err := cursor.Decode(&selectedJob); err != nil {
+		if err := bson.Unmarshal(doc, &selectedJob); err != nil {
 			fmt.Println("Error decoding selected job:", err)
 			continue
 		}
+		pageJobs = append(pageJobs, selectedJob)
+		jobIDs = append(jobIDs, selectedJob.JobID)
+	}
+
+	// The applications are snapshots, the job itself may have closed since
+	statuses, err := repository.FetchJobStatuses(c, db, jobIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching job status"})
+		return
+	}
+
+	// Slice to hold the response data
+	selectedJobs := []dto.SelectedJobResponse{}
+	for _, selectedJob := range pageJobs {
+		jobStatus := models.JobStatusRemoved
+		var closedAt *time.Time
+		if job, ok := statuses[selectedJob.JobID]; ok {
+			jobStatus = repository.JobStatus(job)
+			closedAt = job.ClosedAt
+		}
 
 		// Convert SelectedJobApplication to SelectedJobResponse DTO
 		selectedJobResponse := dto.SelectedJobResponse{
@@ -157,6 +194,8 @@ func (h *SelectedJobsHandler) GetSelectedJobs(c *gin.Context) {
 			CoverLetterGenerated:  selectedJob.CoverLetterGenerated,
 			ViewLink:              selectedJob.ViewLink,
 			SelectedDate:          selectedJob.SelectedDate.Format(time.RFC3339), // Formatting SelectedDate to string
+			JobStatus:             jobStatus,
+			JobClosedAt:           closedAt,
 		}
 
 		// Append the DTO to the response slice
@@ -164,7 +203,7 @@ func (h *SelectedJobsHandler) GetSelectedJobs(c *gin.Context) {
 	}
 
 	// If no selected jobs were found
-	if len(selectedJobs) == 0 {
+	if len(selectedJobs) == 0 && page.Cursor == nil {
 		c.JSON(http.StatusNoContent, gin.H{
 			"message": "No selected jobs found",
 		})
@@ -181,33 +220,20 @@ func (h *SelectedJobsHandler) GetSelectedJobs(c *gin.Context) {
 		return
 	}
 
-	// Create next and prev page URLs for pagination
-	nextPage := ""
-	if int64(offsetInt+limitInt) < totalCount {
-		nextPage = fmt.Sprintf("/api/selected-jobs?offset=%d&limit=%d", offsetInt+limitInt, limitInt)
-	}
-
-	prevPage := ""
-	if offsetInt > 0 {
-		prevPage = fmt.Sprintf("/api/selected-jobs?offset=%d&limit=%d", offsetInt-limitInt, limitInt)
-	}
+	info.Total = &totalCount
 
 	// Send JSON response with selected jobs and pagination info
-	c.JSON(http.StatusOK, gin.H{
-		"selected_jobs": selectedJobs,
-		"pagination": gin.H{
-			"total":     totalCount,
-			"next":      nextPage,
-			"prev":      prevPage,
-			"current":   (offsetInt / limitInt) + 1,
-			"per_page":  limitInt,
-		},
-	})
+	c.JSON(http.StatusOK, repository.Paginated(c, selectedJobs, info))
 }
 
 func convertSalaryRange(modelSalary models.SalaryRange) dto.SalaryRange {
 	return dto.SalaryRange{
-		Min: modelSalary.Min,
-		Max: modelSalary.Max,
+		Min:        modelSalary.Min,
+		Max:        modelSalary.Max,
+		Currency:   modelSalary.Currency,
+		Period:     modelSalary.Period,
+		Source:     modelSalary.Source,
+		Confidence: modelSalary.Confidence,
+		SampleSize: modelSalary.SampleSize,
 	}
 }
\ No newline at end of file
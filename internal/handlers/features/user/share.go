@@ -0,0 +1,273 @@
+package user
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const publicProfileTemplatePath = "./app/templates/publicprofile.html"
+
+var (
+	publicProfileTemplateOnce sync.Once
+	publicProfileTemplate     *template.Template
+	publicProfileTemplateErr  error
+)
+
+type ProfileShareHandler struct{}
+
+// NewProfileShareHandler creates a new ProfileShareHandler
+func NewProfileShareHandler() *ProfileShareHandler {
+	return &ProfileShareHandler{}
+}
+
+// GetVisibility handles GET /profile/visibility
+func (h *ProfileShareHandler) GetVisibility(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var seeker models.Seeker
+	if err := db.Collection("seekers").FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker); err != nil {
+		respondSeekerLookupError(c, userID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"visibility": repository.ProfileVisibilityOf(&seeker)})
+}
+
+// UpdateVisibility handles PUT /profile/visibility
+func (h *ProfileShareHandler) UpdateVisibility(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input dto.ProfileVisibilityRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var seeker models.Seeker
+	if err := db.Collection("seekers").FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker); err != nil {
+		respondSeekerLookupError(c, userID, err)
+		return
+	}
+
+	visibility := repository.ApplyVisibilityRequest(repository.ProfileVisibilityOf(&seeker), input)
+	if _, err := db.Collection("seekers").UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(bson.M{"$set": bson.M{"profile_visibility": visibility}})); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save profile visibility"})
+		log.Printf("Failed to save profile visibility for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"visibility": visibility})
+}
+
+// CreateShare handles POST /profile/shares. Creating a link is what makes the profile public.
+func (h *ProfileShareHandler) CreateShare(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+	sharesCollection := db.Collection("profile_shares")
+
+	var input dto.ProfileShareRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+			return
+		}
+	}
+	if input.ExpiresInDays == 0 {
+		input.ExpiresInDays = repository.DefaultProfileShareDays
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	active, err := sharesCollection.CountDocuments(ctx, bson.M{
+		"auth_user_id": userID,
+		"revoked_at":   bson.M{"$exists": false},
+		"expires_at":   bson.M{"$gt": now},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create share link"})
+		log.Printf("Failed to count profile shares for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	if active >= repository.MaxActiveProfileShares {
+		c.JSON(http.StatusConflict, gin.H{"error": "Too many active share links, revoke one first"})
+		return
+	}
+
+	slug, err := repository.NewShareSlug()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create share link"})
+		log.Printf("Failed to generate share slug: %v", err)
+		return
+	}
+
+	share := models.ProfileShare{
+		AuthUserID: userID,
+		Slug:       slug,
+		CreatedAt:  now,
+		ExpiresAt:  now.AddDate(0, 0, input.ExpiresInDays),
+	}
+	if _, err := sharesCollection.InsertOne(ctx, share); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create share link"})
+		log.Printf("Failed to insert profile share for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"share": share,
+		"path":  "/p/" + slug,
+	})
+}
+
+// GetShares handles GET /profile/shares, newest first
+func (h *ProfileShareHandler) GetShares(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := db.Collection("profile_shares").Find(ctx, bson.M{"auth_user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching share links"})
+		log.Printf("Failed to fetch profile shares for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	shares := []models.ProfileShare{}
+	if err := cursor.All(ctx, &shares); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching share links"})
+		log.Printf("Failed to decode profile shares for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"shares": shares})
+}
+
+// RevokeShare handles DELETE /profile/shares/:slug. The link stops working immediately.
+func (h *ProfileShareHandler) RevokeShare(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := db.Collection("profile_shares").UpdateOne(ctx,
+		bson.M{"auth_user_id": userID, "slug": c.Param("slug"), "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": time.Now()}},
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke share link"})
+		log.Printf("Failed to revoke profile share for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Share link not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Share link revoked"})
+}
+
+// GetPublicProfile handles GET /p/:slug without authentication. Browsers get the HTML page,
+// clients sending "Accept: application/json" (or ?format=json) get the DTO.
+func (h *ProfileShareHandler) GetPublicProfile(c *gin.Context) {
+	db := c.MustGet("db").(*mongo.Database)
+	slug := c.Param("slug")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	share, err := repository.FindProfileShare(ctx, db, slug)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrShareNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		case errors.Is(err, repository.ErrShareExpired):
+			c.JSON(http.StatusGone, gin.H{"error": "This profile link has expired"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving profile"})
+			log.Printf("Failed to load profile share %s: %v", slug, err)
+		}
+		return
+	}
+
+	var seeker models.Seeker
+	if err := db.Collection("seekers").FindOne(ctx, bson.M{"auth_user_id": share.AuthUserID}).Decode(&seeker); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		log.Printf("Seeker for profile share %s not found: %v", slug, err)
+		return
+	}
+
+	visibility := repository.ProfileVisibilityOf(&seeker)
+	var authUser *models.AuthUser
+	if visibility.Contact {
+		var user models.AuthUser
+		if err := db.Collection("auth_users").FindOne(ctx, bson.M{"auth_user_id": share.AuthUserID}).Decode(&user); err != nil {
+			log.Printf("Auth user for profile share %s not found: %v", slug, err)
+		} else {
+			authUser = &user
+		}
+	}
+	profile := repository.BuildPublicProfile(&seeker, authUser, visibility)
+
+	if err := repository.RecordShareView(ctx, db, slug); err != nil {
+		log.Printf("Failed to count view of profile share %s: %v", slug, err)
+	}
+
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	c.Header("Cache-Control", "no-store")
+
+	if c.Query("format") == "json" || c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON {
+		c.JSON(http.StatusOK, profile)
+		return
+	}
+
+	publicProfileTemplateOnce.Do(func() {
+		publicProfileTemplate, publicProfileTemplateErr = template.ParseFiles(publicProfileTemplatePath)
+	})
+	if publicProfileTemplateErr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error rendering profile"})
+		log.Printf("Failed to parse %s: %v", publicProfileTemplatePath, publicProfileTemplateErr)
+		return
+	}
+
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	if err := publicProfileTemplate.Execute(c.Writer, profile); err != nil {
+		log.Printf("Failed to render profile share %s: %v", slug, err)
+	}
+}
+
+func respondSeekerLookupError(c *gin.Context, userID string, err error) {
+	if err == mongo.ErrNoDocuments {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Seeker not found"})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving seeker"})
+	}
+	log.Printf("Error retrieving seeker for auth_user_id: %s, Error: %v", userID, err)
+}
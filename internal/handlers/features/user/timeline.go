@@ -2,7 +2,7 @@ package user
 
 import (
 
-	"RAAS/internal/models"
+	"RAAS/internal/handlers/repository"
 
 	"fmt"
 	"net/http"
@@ -27,7 +27,7 @@ func GetNextEntryStep() gin.HandlerFunc {
 		}
 		
 		collection := db.Collection("user_entry_timelines")
-		var timeline models.UserEntryTimeline
+		var timeline bson.M
 		err := collection.FindOne(c, bson.M{"auth_user_id": userID}).Decode(&timeline)
 		if err != nil {
 			if err == mongo.ErrNoDocuments {
@@ -40,32 +40,40 @@ func GetNextEntryStep() gin.HandlerFunc {
 			return
 		}
 
-		steps := []struct {
-			Name      string
-			Completed bool
-			Required  bool
-		}{
-			{"personal_infos", timeline.PersonalInfosCompleted, timeline.PersonalInfosRequired},
-			{"professional_summaries", timeline.ProfessionalSummariesCompleted, timeline.ProfessionalSummariesRequired},
-			{"work_experiences", timeline.WorkExperiencesCompleted, timeline.WorkExperiencesRequired},
-			{"educations", timeline.EducationsCompleted, timeline.EducationsRequired},
-			{"certificates", timeline.CertificatesCompleted, timeline.CertificatesRequired},
-			{"languages", timeline.LanguagesCompleted, timeline.LanguagesRequired},
-			{"preferred_job_titles", timeline.PreferredJobTitlesCompleted, timeline.PreferredJobTitlesRequired},
+		var seekerDoc bson.M
+		if err := db.Collection("seekers").FindOne(c, bson.M{"auth_user_id": userID}).Decode(&seekerDoc); err != nil {
+			fmt.Println("Error fetching seeker:", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch seeker"})
+			return
+		}
+		tier, _ := seekerDoc["subscription_tier"].(string)
+
+		steps, err := repository.LoadOnboardingSteps(c, db)
+		if err != nil {
+			fmt.Println("Error loading onboarding steps:", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load onboarding steps"})
+			return
 		}
-	
+
 		for _, step := range steps {
-			fmt.Printf("Checking step: %s, Completed: %v, Required: %v\n", step.Name, step.Completed, step.Required)
-			if step.Required && !step.Completed {
+			required := repository.IsStepRequired(step, tier)
+			completed := repository.IsStepCompleted(step, seekerDoc, timeline)
+			if required && !completed {
+				if done, _ := timeline["completed"].(bool); done {
+					// Rules changed since the timeline was completed
+					if _, err := collection.UpdateOne(c, bson.M{"auth_user_id": userID}, bson.M{"$set": bson.M{"completed": false}}); err != nil {
+						fmt.Println("Error updating timeline:", err)
+					}
+				}
 				c.JSON(http.StatusOK, gin.H{
 					"completed": false,
-					"next_step": step.Name,
+					"next_step": step.Key,
 				})
 				return
 			}
 		}
 
-		if !timeline.Completed {
+		if done, _ := timeline["completed"].(bool); !done {
 			update := bson.M{
 				"$set": bson.M{"completed": true},
 			}
@@ -7,6 +7,7 @@ import (
 	"RAAS/internal/handlers/repository"
 
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"time"
@@ -73,6 +74,10 @@ func (h *CertificateHandler) CreateCertificate(c *gin.Context) {
 
 	// Append the new certificate
 	if err := repository.AppendToCertificates(&seeker, input, fileURL); err != nil {
+		if errors.Is(err, repository.ErrInvalidCertificate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process certificate"})
 		log.Printf("Failed to process certificate for auth_user_id: %s, Error: %v", userID, err)
 		return
@@ -85,7 +90,8 @@ func (h *CertificateHandler) CreateCertificate(c *gin.Context) {
 		},
 	}
 
-	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, update)
+	// Only written at the version the list was read from, so two tabs or a PUT /profile do not overwrite each other
+	updateResult, err := seekersCollection.UpdateOne(ctx, repository.VersionFilter(userID, seeker.Version), repository.BumpVersion(update))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save certificate"})
 		log.Printf("Failed to update certificate for auth_user_id: %s, Error: %v", userID, err)
@@ -93,8 +99,7 @@ func (h *CertificateHandler) CreateCertificate(c *gin.Context) {
 	}
 
 	if updateResult.MatchedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No matching seeker found to update"})
-		log.Printf("No matching seeker found for auth_user_id: %s", userID)
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": repository.ErrVersionConflict.Error()})
 		return
 	}
 
@@ -110,6 +115,8 @@ func (h *CertificateHandler) CreateCertificate(c *gin.Context) {
 		return
 	}
 
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Certificate added successfully",
 	})
@@ -149,8 +156,9 @@ func (h *CertificateHandler) GetCertificates(c *gin.Context) {
 		return
 	}
 
+	// Expired certificates stay listed, flagged so the seeker can renew them
 	c.JSON(http.StatusOK, gin.H{
-		"certificates": certificates,
+		"certificates": repository.AnnotateCertificates(certificates, time.Now()),
 	})
 }
 
@@ -35,6 +35,13 @@ func (h *EducationHandler) CreateEducation(c *gin.Context) {
 		return
 	}
 
+	// A null or empty end date marks ongoing study
+	input.EndDate = repository.NormalizeEndDate(input.EndDate)
+	if errs := repository.ValidateDateRange(repository.EducationDateRange, input.StartDate, input.EndDate, time.Now()); len(errs) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid dates", "details": errs})
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -74,7 +81,8 @@ func (h *EducationHandler) CreateEducation(c *gin.Context) {
 		},
 	}
 
-	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, update)
+	// Only written at the version the list was read from, so two tabs or a PUT /profile do not overwrite each other
+	updateResult, err := seekersCollection.UpdateOne(ctx, repository.VersionFilter(userID, seeker.Version), repository.BumpVersion(update))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save education"})
 		log.Printf("Failed to update education for auth_user_id: %s, Error: %v", userID, err)
@@ -82,8 +90,7 @@ func (h *EducationHandler) CreateEducation(c *gin.Context) {
 	}
 
 	if updateResult.MatchedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No matching seeker found to update"})
-		log.Printf("No matching seeker found for auth_user_id: %s", userID)
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": repository.ErrVersionConflict.Error()})
 		return
 	}
 
@@ -100,6 +107,8 @@ func (h *EducationHandler) CreateEducation(c *gin.Context) {
 		return
 	}
 
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Education added successfully",
 	})
@@ -0,0 +1,108 @@
+package preference
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type JobPreferencesHandler struct{}
+
+// NewJobPreferencesHandler creates a new JobPreferencesHandler
+func NewJobPreferencesHandler() *JobPreferencesHandler {
+	return &JobPreferencesHandler{}
+}
+
+// PutJobPreferences handles PUT /job-preferences, replacing the seeker's job-search preferences
+func (h *JobPreferencesHandler) PutJobPreferences(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+	seekersCollection := db.Collection("seekers")
+	entryTimelineCollection := db.Collection("user_entry_timelines")
+
+	var input dto.JobPreferencesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		log.Printf("Error binding input: %v", err)
+		return
+	}
+
+	preferences, err := repository.NormalizeJobPreferences(input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(bson.M{"$set": bson.M{"job_preferences": preferences}}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save job preferences"})
+		log.Printf("Failed to update job preferences for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	if updateResult.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Seeker not found"})
+		log.Printf("No matching seeker found for auth_user_id: %s", userID)
+		return
+	}
+
+	// Update user entry timeline to mark job preferences completed
+	timelineUpdate := bson.M{
+		"$set": bson.M{
+			"job_preferences_completed": true,
+		},
+	}
+	if _, err := entryTimelineCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, timelineUpdate); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user entry timeline"})
+		log.Printf("Failed to update user entry timeline for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "Job preferences saved successfully",
+		"job_preferences": preferences,
+	})
+}
+
+// GetJobPreferences handles GET /job-preferences
+func (h *JobPreferencesHandler) GetJobPreferences(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var seeker models.Seeker
+	if err := db.Collection("seekers").FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Seeker not found"})
+			log.Printf("Seeker not found for auth_user_id: %s", userID)
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving seeker"})
+			log.Printf("Error retrieving seeker for auth_user_id: %s, Error: %v", userID, err)
+		}
+		return
+	}
+
+	if seeker.JobPreferences == nil {
+		c.JSON(http.StatusNoContent, gin.H{"message": "No job preferences found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job_preferences": seeker.JobPreferences,
+	})
+}
@@ -3,15 +3,19 @@ package preference
 import (
 	"RAAS/internal/models"
 	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
 
 	"context"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type JobTitleHandler struct{}
@@ -54,22 +58,34 @@ func (h *JobTitleHandler) CreateJobTitleOnce(c *gin.Context) {
 	// Check if job titles are already set
 	if seeker.PrimaryTitle != "" {
 		c.JSON(http.StatusForbidden, gin.H{
-			"error": "Job titles are already set and cannot be changed.",
+			"error": "Job titles are already set, use PATCH /jobtitles to change them.",
 		})
 		log.Printf("Attempt to modify existing job titles by auth_user_id: %s", userID)
 		return
 	}
 
+	titles := models.JobTitleSet{
+		PrimaryTitle:   repository.NormalizeJobTitle(ctx, db, input.PrimaryTitle),
+		SecondaryTitle: repository.NormalizeOptionalJobTitle(ctx, db, input.SecondaryTitle),
+		TertiaryTitle:  repository.NormalizeOptionalJobTitle(ctx, db, input.TertiaryTitle),
+	}
+	if titles.PrimaryTitle == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "primary_title is required"})
+		return
+	}
+	now := time.Now()
+
 	// Titles not set yet: proceed with update
 	update := bson.M{
 		"$set": bson.M{
-			"primary_title":   input.PrimaryTitle,
-			"secondary_title": input.SecondaryTitle,
-			"tertiary_title":  input.TertiaryTitle,
+			"primary_title":         titles.PrimaryTitle,
+			"secondary_title":       titles.SecondaryTitle,
+			"tertiary_title":        titles.TertiaryTitle,
+			"job_titles_updated_at": now,
 		},
 	}
 
-	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, update)
+	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(update))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job titles", "details": err.Error()})
 		log.Printf("Failed to update job titles for auth_user_id: %s, Error: %v", userID, err)
@@ -93,6 +109,12 @@ func (h *JobTitleHandler) CreateJobTitleOnce(c *gin.Context) {
 		// Not fatal for the main request, so no return
 	}
 
+	if err := repository.RecordJobTitleChange(ctx, db, userID, models.JobTitleSet{}, titles, now); err != nil {
+		log.Printf("Failed to record job title history for auth_user_id: %s, Error: %v", userID, err)
+	}
+
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Job titles set successfully"})
 }
 
@@ -133,40 +155,121 @@ func (h *JobTitleHandler) GetJobTitle(c *gin.Context) {
 	c.JSON(http.StatusOK, jobTitleResponse)
 }
 
+// UpdateJobTitles handles PATCH /jobtitles. Only the provided titles change; an empty secondary or
+// tertiary title clears it. Changes are limited by a per-tier cooldown and recorded in the history.
+func (h *JobTitleHandler) UpdateJobTitles(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+	seekersCollection := db.Collection("seekers")
 
+	var input dto.JobTitleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
 
-// // PatchJobTitle allows partial update of job titles for the authenticated user
-// func (h *JobTitleHandler) PatchJobTitle(c *gin.Context) {
-// 	userID := c.MustGet("userID").(uuid.UUID)
-// 	var input dto.JobTitleInput
-
-// 	if err := c.ShouldBindJSON(&input); err != nil {
-// 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
-// 		return
-// 	}
-
-// 	// Find the Seeker model by AuthUserID
-// 	var seeker models.Seeker
-// 	if err := h.DB.Where("auth_user_id = ?", userID).First(&seeker).Error; err != nil {
-// 		c.JSON(http.StatusNotFound, gin.H{"error": "Seeker not found", "details": err.Error()})
-// 		return
-// 	}
-
-// 	// Update only the fields that are provided
-// 	if input.PrimaryTitle != "" {
-// 		seeker.PrimaryTitle = input.PrimaryTitle
-// 	}
-// 	if input.SecondaryTitle != nil {
-// 		seeker.SecondaryTitle = input.SecondaryTitle
-// 	}
-// 	if input.TertiaryTitle != nil {
-// 		seeker.TertiaryTitle = input.TertiaryTitle
-// 	}
-
-// 	if err := h.DB.Save(&seeker).Error; err != nil {
-// 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to patch job title", "details": err.Error()})
-// 		return
-// 	}
-
-// 	c.JSON(http.StatusOK, gin.H{"message": "Job title patched successfully", "jobTitle": seeker})
-// }
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var seeker models.Seeker
+	if err := seekersCollection.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Seeker not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving seeker"})
+		}
+		log.Printf("Error retrieving seeker for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	if seeker.PrimaryTitle == "" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Job titles are not set yet, use POST /jobtitles first."})
+		return
+	}
+
+	previous := models.JobTitleSet{
+		PrimaryTitle:   seeker.PrimaryTitle,
+		SecondaryTitle: seeker.SecondaryTitle,
+		TertiaryTitle:  seeker.TertiaryTitle,
+	}
+	titles := previous
+	if strings.TrimSpace(input.PrimaryTitle) != "" {
+		titles.PrimaryTitle = repository.NormalizeJobTitle(ctx, db, input.PrimaryTitle)
+	}
+	if input.SecondaryTitle != nil {
+		titles.SecondaryTitle = repository.NormalizeOptionalJobTitle(ctx, db, input.SecondaryTitle)
+	}
+	if input.TertiaryTitle != nil {
+		titles.TertiaryTitle = repository.NormalizeOptionalJobTitle(ctx, db, input.TertiaryTitle)
+	}
+
+	if repository.SameJobTitles(previous, titles) {
+		c.JSON(http.StatusOK, gin.H{"message": "Job titles unchanged", "job_titles": titles})
+		return
+	}
+
+	// Enforce the cooldown of the seeker's subscription tier
+	if seeker.JobTitlesUpdatedAt != nil {
+		nextChange := seeker.JobTitlesUpdatedAt.Add(repository.JobTitleCooldown(seeker.SubscriptionTier))
+		if wait := time.Until(nextChange); wait > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":          "Job titles were changed recently",
+				"next_change_at": nextChange,
+			})
+			return
+		}
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"primary_title":         titles.PrimaryTitle,
+			"secondary_title":       titles.SecondaryTitle,
+			"tertiary_title":        titles.TertiaryTitle,
+			"job_titles_updated_at": now,
+		},
+	}
+	if _, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(update)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job titles", "details": err.Error()})
+		log.Printf("Failed to update job titles for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	if err := repository.RecordJobTitleChange(ctx, db, userID, previous, titles, now); err != nil {
+		log.Printf("Failed to record job title history for auth_user_id: %s, Error: %v", userID, err)
+	}
+
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Job titles updated successfully",
+		"job_titles":     titles,
+		"next_change_at": now.Add(repository.JobTitleCooldown(seeker.SubscriptionTier)),
+	})
+}
+
+// GetJobTitleHistory handles GET /jobtitles/history, newest change first
+func (h *JobTitleHandler) GetJobTitleHistory(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "changed_at", Value: -1}}).SetLimit(100)
+	cursor, err := db.Collection("job_title_history").Find(ctx, bson.M{"auth_user_id": userID}, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving job title history"})
+		log.Printf("Error retrieving job title history for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+
+	history := []models.JobTitleChange{}
+	if err := cursor.All(ctx, &history); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error decoding job title history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": history})
+}
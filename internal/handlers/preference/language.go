@@ -8,6 +8,7 @@ import (
 
 
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"time"
@@ -71,10 +72,17 @@ func (h *LanguageHandler) CreateLanguage(c *gin.Context) {
 		return
 	}
 
-	// Append the new language
+	// Append the new language, rejecting unknown languages, non-CEFR levels and duplicates
 	if err := repository.AppendToLanguages(&seeker, input, fileURL); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process language"})
-		log.Printf("Failed to process language for auth_user_id: %s, Error: %v", userID, err)
+		switch {
+		case errors.Is(err, repository.ErrDuplicateLanguage):
+			c.JSON(http.StatusConflict, gin.H{"error": "Language already added", "details": err.Error()})
+		case errors.Is(err, repository.ErrUnknownLanguage), errors.Is(err, repository.ErrInvalidProficiency), errors.Is(err, repository.ErrCertificateNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process language"})
+			log.Printf("Failed to process language for auth_user_id: %s, Error: %v", userID, err)
+		}
 		return
 	}
 
@@ -85,7 +93,8 @@ func (h *LanguageHandler) CreateLanguage(c *gin.Context) {
 		},
 	}
 
-	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, update)
+	// Only written at the version the list was read from, so two tabs or a PUT /profile do not overwrite each other
+	updateResult, err := seekersCollection.UpdateOne(ctx, repository.VersionFilter(userID, seeker.Version), repository.BumpVersion(update))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save language"})
 		log.Printf("Failed to update language for auth_user_id: %s, Error: %v", userID, err)
@@ -93,8 +102,7 @@ func (h *LanguageHandler) CreateLanguage(c *gin.Context) {
 	}
 
 	if updateResult.MatchedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No matching seeker found to update"})
-		log.Printf("No matching seeker found for auth_user_id: %s", userID)
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": repository.ErrVersionConflict.Error()})
 		return
 	}
 
@@ -110,6 +118,8 @@ func (h *LanguageHandler) CreateLanguage(c *gin.Context) {
 		return
 	}
 
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Language added successfully",
 	})
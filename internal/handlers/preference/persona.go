@@ -0,0 +1,237 @@
+package preference
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PersonaHandler struct{}
+
+// NewPersonaHandler creates a new PersonaHandler
+func NewPersonaHandler() *PersonaHandler {
+	return &PersonaHandler{}
+}
+
+// CreatePersona handles POST /personas
+func (h *PersonaHandler) CreatePersona(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input dto.PersonaRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seeker, ok := findSeeker(ctx, c, db, userID)
+	if !ok {
+		return
+	}
+
+	if len(seeker.Personas) >= repository.MaxPersonas {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("A seeker can have at most %d personas", repository.MaxPersonas)})
+		return
+	}
+
+	persona, err := buildPersona(ctx, db, &seeker, "", input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+	persona.PersonaID = uuid.New().String()
+	persona.CreatedAt = persona.UpdatedAt
+
+	seeker.Personas = append(seeker.Personas, persona)
+	if !savePersonas(ctx, c, db, &seeker) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, persona)
+}
+
+// GetPersonas handles GET /personas
+func (h *PersonaHandler) GetPersonas(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seeker, ok := findSeeker(ctx, c, db, userID)
+	if !ok {
+		return
+	}
+
+	personas := seeker.Personas
+	if personas == nil {
+		personas = []models.Persona{}
+	}
+	// Work experiences deleted since the persona was saved are not reported
+	for i := range personas {
+		if personas[i].WorkExperienceIDs != nil {
+			personas[i].WorkExperienceIDs = repository.LiveWorkExperienceIDs(&seeker, personas[i].WorkExperienceIDs)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"personas": personas})
+}
+
+// UpdatePersona handles PUT /personas/:id, replacing the persona overrides
+func (h *PersonaHandler) UpdatePersona(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input dto.PersonaRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seeker, ok := findSeeker(ctx, c, db, userID)
+	if !ok {
+		return
+	}
+
+	existing, index := repository.FindPersona(&seeker, c.Param("id"))
+	if existing == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Persona not found"})
+		return
+	}
+
+	persona, err := buildPersona(ctx, db, &seeker, existing.PersonaID, input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+	persona.PersonaID = existing.PersonaID
+	persona.CreatedAt = existing.CreatedAt
+
+	seeker.Personas[index] = persona
+	if !savePersonas(ctx, c, db, &seeker) {
+		return
+	}
+
+	c.JSON(http.StatusOK, persona)
+}
+
+// DeletePersona handles DELETE /personas/:id
+func (h *PersonaHandler) DeletePersona(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := db.Collection("seekers").UpdateOne(ctx,
+		bson.M{"auth_user_id": userID, "personas.persona_id": c.Param("id")},
+		repository.BumpVersion(bson.M{"$pull": bson.M{"personas": bson.M{"persona_id": c.Param("id")}}}),
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete persona"})
+		log.Printf("Failed to delete persona for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Persona not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Persona deleted successfully"})
+}
+
+func findSeeker(ctx context.Context, c *gin.Context, db *mongo.Database, userID string) (models.Seeker, bool) {
+	var seeker models.Seeker
+	if err := db.Collection("seekers").FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker); err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Seeker not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving seeker"})
+		}
+		log.Printf("Error retrieving seeker for auth_user_id: %s, Error: %v", userID, err)
+		return seeker, false
+	}
+	return seeker, true
+}
+
+// savePersonas writes the personas of the seeker as read. The write only applies at the version that was read,
+// so that a concurrent persona or work experience change is not overwritten; the client retries on 409.
+func savePersonas(ctx context.Context, c *gin.Context, db *mongo.Database, seeker *models.Seeker) bool {
+	result, err := db.Collection("seekers").UpdateOne(ctx,
+		repository.VersionFilter(seeker.AuthUserID, seeker.Version),
+		repository.BumpVersion(bson.M{"$set": bson.M{"personas": seeker.Personas}}),
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save persona"})
+		log.Printf("Failed to save personas for auth_user_id: %s, Error: %v", seeker.AuthUserID, err)
+		return false
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": repository.ErrVersionConflict.Error()})
+		return false
+	}
+	return true
+}
+
+// buildPersona validates the request against the base profile and normalizes titles and skills
+func buildPersona(ctx context.Context, db *mongo.Database, seeker *models.Seeker, personaID string, input dto.PersonaRequest) (models.Persona, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return models.Persona{}, fmt.Errorf("name is required")
+	}
+	// Names identify personas in the UI, keep them unique
+	for _, existing := range seeker.Personas {
+		if strings.EqualFold(existing.Name, name) && existing.PersonaID != personaID {
+			return models.Persona{}, fmt.Errorf("a persona named %q already exists", existing.Name)
+		}
+	}
+
+	persona := models.Persona{
+		Name:           name,
+		PrimaryTitle:   repository.NormalizeJobTitle(ctx, db, input.PrimaryTitle),
+		SecondaryTitle: repository.NormalizeOptionalJobTitle(ctx, db, input.SecondaryTitle),
+		TertiaryTitle:  repository.NormalizeOptionalJobTitle(ctx, db, input.TertiaryTitle),
+		UpdatedAt:      time.Now(),
+	}
+	if persona.PrimaryTitle == "" {
+		return models.Persona{}, fmt.Errorf("primary_title is required")
+	}
+
+	if input.Summary != nil && strings.TrimSpace(*input.Summary) != "" {
+		summary := strings.TrimSpace(*input.Summary)
+		persona.Summary = &summary
+	}
+	if len(input.Skills) > 0 {
+		persona.Skills = repository.NormalizeSkills(ctx, db, input.Skills)
+	}
+
+	seen := map[string]bool{}
+	for _, id := range input.WorkExperienceIDs {
+		if repository.FindWorkExperience(seeker, id) < 0 {
+			return models.Persona{}, fmt.Errorf("work_experience_ids: %q does not match a work experience", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			persona.WorkExperienceIDs = append(persona.WorkExperienceIDs, id)
+		}
+	}
+
+	return persona, nil
+}
@@ -68,7 +68,7 @@ func (h *PersonalInfoHandler) CreatePersonalInfo(c *gin.Context) {
 	}
 
 	// Update personal info in MongoDB (seekers collection)
-	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": bson.M{"personal_info": seeker.PersonalInfo}})
+	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(bson.M{"$set": bson.M{"personal_info": seeker.PersonalInfo}}))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save personal info"})
 		log.Printf("Failed to update personal info for auth_user_id: %s, Error: %s", userID, err.Error())
@@ -105,6 +105,8 @@ func (h *PersonalInfoHandler) CreatePersonalInfo(c *gin.Context) {
 	}
 
 
+	repository.RefreshProfileCompletion(ctx, c.MustGet("db").(*mongo.Database), userID)
+
 	// Respond with appropriate message
 	c.JSON(http.StatusOK, gin.H{
 		"message": message,
@@ -173,12 +175,14 @@ func (h *PersonalInfoHandler) UpdatePersonalInfo(c *gin.Context) {
 		return
 	}
 
-	_, err = collection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": bson.M{"personal_info": seeker.PersonalInfo}})
+	_, err = collection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(bson.M{"$set": bson.M{"personal_info": seeker.PersonalInfo}}))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update personal info"})
 		return
 	}
 
+	repository.RefreshProfileCompletion(ctx, c.MustGet("db").(*mongo.Database), userID)
+
 	c.JSON(http.StatusOK, dto.PersonalInfoResponse{
 		AuthUserID:      userID,
 		FirstName:       input.FirstName,
@@ -238,11 +242,18 @@ func (h *PersonalInfoHandler) PatchPersonalInfo(c *gin.Context) {
 		return
 	}
 
-	_, err = collection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": bson.M{"personal_info": seeker.PersonalInfo}})
+	// The patch is merged into the stored info, so it is only written at the version it was read from
+	result, err := collection.UpdateOne(ctx, repository.VersionFilter(userID, seeker.Version), repository.BumpVersion(bson.M{"$set": bson.M{"personal_info": seeker.PersonalInfo}}))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save patched personal info"})
 		return
 	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": repository.ErrVersionConflict.Error()})
+		return
+	}
+
+	repository.RefreshProfileCompletion(ctx, c.MustGet("db").(*mongo.Database), userID)
 
 	c.JSON(http.StatusOK, personalInfo)
 }
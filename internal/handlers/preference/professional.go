@@ -37,6 +37,9 @@ func (h *ProfessionalSummaryHandler) CreateProfessionalSummary(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Map free-text skills onto the skills taxonomy
+	input.Skills = repository.NormalizeSkills(ctx, c.MustGet("db").(*mongo.Database), input.Skills)
+
 	var seeker models.Seeker
 	err := seekersCollection.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker)
 	if err != nil {
@@ -50,7 +53,7 @@ func (h *ProfessionalSummaryHandler) CreateProfessionalSummary(c *gin.Context) {
 		return
 	}
 
-	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": bson.M{"professional_summary": seeker.ProfessionalSummary}})
+	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(bson.M{"$set": bson.M{"professional_summary": seeker.ProfessionalSummary}}))
 	if err != nil || updateResult.MatchedCount == 0 {
 		handleDBError(err, c, "Failed to save professional summary", userID)
 		return
@@ -69,6 +72,8 @@ func (h *ProfessionalSummaryHandler) CreateProfessionalSummary(c *gin.Context) {
 		return
 	}
 
+	repository.RefreshProfileCompletion(ctx, c.MustGet("db").(*mongo.Database), userID)
+
 	c.JSON(http.StatusOK, gin.H{"message": message})
 }
 
@@ -121,6 +126,9 @@ func (h *ProfessionalSummaryHandler) UpdateProfessionalSummary(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Map free-text skills onto the skills taxonomy
+	input.Skills = repository.NormalizeSkills(ctx, c.MustGet("db").(*mongo.Database), input.Skills)
+
 	var seeker models.Seeker
 	err := seekersCollection.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seeker)
 	if err != nil {
@@ -134,12 +142,14 @@ func (h *ProfessionalSummaryHandler) UpdateProfessionalSummary(c *gin.Context) {
 	}
 
 	update := bson.M{"$set": bson.M{"professional_summary": seeker.ProfessionalSummary}}
-	_, err = seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, update)
+	_, err = seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(update))
 	if err != nil {
 		handleDBError(err, c, "Database update failed", userID)
 		return
 	}
 
+	repository.RefreshProfileCompletion(ctx, c.MustGet("db").(*mongo.Database), userID)
+
 	c.JSON(http.StatusOK, dto.ProfessionalSummaryResponse{
 		AuthUserID:   seeker.AuthUserID,
 		About:        input.About,
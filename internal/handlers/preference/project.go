@@ -0,0 +1,235 @@
+package preference
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/handlers/repository"
+	"RAAS/internal/models"
+
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ProjectHandler struct{}
+
+// NewProjectHandler creates a new ProjectHandler
+func NewProjectHandler() *ProjectHandler {
+	return &ProjectHandler{}
+}
+
+// CreateProject handles POST /projects
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input dto.ProjectRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seeker, ok := findSeeker(ctx, c, db, userID)
+	if !ok {
+		return
+	}
+	if len(seeker.Projects) >= repository.MaxProjects {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("A seeker can have at most %d projects", repository.MaxProjects)})
+		return
+	}
+
+	project, errs := repository.BuildProject(ctx, db, input, time.Now())
+	if len(errs) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid project", "details": errs})
+		return
+	}
+	project.ID = primitive.NewObjectID()
+	project.CreatedAt = project.UpdatedAt
+
+	if !saveProjects(ctx, c, db, &seeker, append(seeker.Projects, project)) {
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+// GetProjects handles GET /projects
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seeker, ok := findSeeker(ctx, c, db, userID)
+	if !ok {
+		return
+	}
+
+	projects := seeker.Projects
+	if projects == nil {
+		projects = []models.Project{}
+	}
+	c.JSON(http.StatusOK, gin.H{"projects": projects})
+}
+
+// UpdateProject handles PUT /projects/:id, replacing the project
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input dto.ProjectRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seeker, ok := findSeeker(ctx, c, db, userID)
+	if !ok {
+		return
+	}
+
+	index := repository.FindProject(&seeker, c.Param("id"))
+	if index < 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	project, errs := repository.BuildProject(ctx, db, input, time.Now())
+	if len(errs) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid project", "details": errs})
+		return
+	}
+	project.ID = seeker.Projects[index].ID
+	project.CreatedAt = seeker.Projects[index].CreatedAt
+
+	seeker.Projects[index] = project
+	if !saveProjects(ctx, c, db, &seeker, seeker.Projects) {
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject handles DELETE /projects/:id
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seeker, ok := findSeeker(ctx, c, db, userID)
+	if !ok {
+		return
+	}
+
+	index := repository.FindProject(&seeker, c.Param("id"))
+	if index < 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	projects := append(seeker.Projects[:index:index], seeker.Projects[index+1:]...)
+	if !saveProjects(ctx, c, db, &seeker, projects) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+}
+
+// GetTools handles GET /tools
+func (h *ProjectHandler) GetTools(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seeker, ok := findSeeker(ctx, c, db, userID)
+	if !ok {
+		return
+	}
+
+	tools := seeker.Tools
+	if tools == nil {
+		tools = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{"tools": tools})
+}
+
+// UpdateTools handles PUT /tools, replacing the tools list
+func (h *ProjectHandler) UpdateTools(c *gin.Context) {
+	userID := c.MustGet("userID").(string)
+	db := c.MustGet("db").(*mongo.Database)
+
+	var input dto.ToolsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tools := repository.NormalizeTools(ctx, db, input.Tools)
+	result, err := db.Collection("seekers").UpdateOne(ctx, bson.M{"auth_user_id": userID}, repository.BumpVersion(bson.M{"$set": bson.M{"tools": tools}}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save tools"})
+		log.Printf("Failed to save tools for auth_user_id: %s, Error: %v", userID, err)
+		return
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Seeker not found"})
+		return
+	}
+
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
+	c.JSON(http.StatusOK, gin.H{"tools": tools})
+}
+
+// saveProjects stores the portfolio and keeps the "projects" timeline step in sync. Like personas, the list
+// is only written at the seeker version it was read from, a concurrent change is answered with 409.
+func saveProjects(ctx context.Context, c *gin.Context, db *mongo.Database, seeker *models.Seeker, projects []models.Project) bool {
+	userID := seeker.AuthUserID
+	result, err := db.Collection("seekers").UpdateOne(ctx,
+		repository.VersionFilter(userID, seeker.Version),
+		repository.BumpVersion(bson.M{"$set": bson.M{"projects": projects}}),
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save project"})
+		log.Printf("Failed to save projects for auth_user_id: %s, Error: %v", userID, err)
+		return false
+	}
+	if result.MatchedCount == 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": repository.ErrVersionConflict.Error()})
+		return false
+	}
+
+	timelineUpdate := bson.M{
+		"$set": bson.M{
+			"projects_completed": len(projects) > 0,
+		},
+	}
+	if _, err := db.Collection("user_entry_timelines").UpdateOne(ctx, bson.M{"auth_user_id": userID}, timelineUpdate); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user entry timeline"})
+		log.Printf("Failed to update user entry timeline for auth_user_id: %s, Error: %v", userID, err)
+		return false
+	}
+
+	repository.RefreshProfileCompletion(ctx, db, userID)
+	return true
+}
@@ -36,6 +36,13 @@ func (h *WorkExperienceHandler) CreateWorkExperience(c *gin.Context) {
 		return
 	}
 
+	// A null or empty end date marks the current position
+	input.EndDate = repository.NormalizeEndDate(input.EndDate)
+	if errs := repository.ValidateDateRange(repository.WorkDateRange, input.StartDate, input.EndDate, time.Now()); len(errs) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid dates", "details": errs})
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -61,6 +68,9 @@ func (h *WorkExperienceHandler) CreateWorkExperience(c *gin.Context) {
 		KeyResponsibilities: input.KeyResponsibilities,
 	}
 
+	// Overlapping full-time positions are saved, but reported back for the seeker to double-check
+	warnings := repository.WorkOverlapWarnings(seeker.WorkExperiences, input.EmploymentType, input.StartDate, input.EndDate)
+
 	// Use AppendToWorkExperience to add the new experience
 	if err := repository.AppendToWorkExperience(&seeker, workExperience); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process work experience"})
@@ -75,7 +85,8 @@ func (h *WorkExperienceHandler) CreateWorkExperience(c *gin.Context) {
 		},
 	}
 
-	updateResult, err := seekersCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, update)
+	// Only written at the version the list was read from, so two tabs or a PUT /profile do not overwrite each other
+	updateResult, err := seekersCollection.UpdateOne(ctx, repository.VersionFilter(userID, seeker.Version), repository.BumpVersion(update))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save work experience"})
 		log.Printf("Failed to update work experience for auth_user_id: %s, Error: %v", userID, err)
@@ -83,8 +94,7 @@ func (h *WorkExperienceHandler) CreateWorkExperience(c *gin.Context) {
 	}
 
 	if updateResult.MatchedCount == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No matching seeker found to update"})
-		log.Printf("No matching seeker found for auth_user_id: %s", userID)
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": repository.ErrVersionConflict.Error()})
 		return
 	}
 
@@ -101,9 +111,15 @@ func (h *WorkExperienceHandler) CreateWorkExperience(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	repository.RefreshProfileCompletion(ctx, db, userID)
+
+	response := gin.H{
 		"message": "Work experience added successfully",
-	})
+	}
+	if len(warnings) > 0 {
+		response["warnings"] = warnings
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // GetWorkExperienceHandler handles the retrieval of a user's work experiences
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PurgeUserData removes every document of the user from models.UserDataCollections and the
+// blobs those documents reference (uploads, generated documents, data export archives).
+// The auth user goes last, so a failed purge is picked up again on the next run.
+func PurgeUserData(ctx context.Context, db *mongo.Database, userID string, deleteBlob func(ctx context.Context, fileURL string) error) error {
+	fileURLs := map[string]bool{}
+	for _, collectionName := range models.UserDataCollections {
+		cursor, err := db.Collection(collectionName).Find(ctx, bson.M{"auth_user_id": userID})
+		if err != nil {
+			return err
+		}
+		var documents []bson.M
+		if err := cursor.All(ctx, &documents); err != nil {
+			return err
+		}
+		for _, doc := range documents {
+			collectBlobURLs(doc, fileURLs)
+		}
+	}
+
+	urls := make([]string, 0, len(fileURLs))
+	for fileURL := range fileURLs {
+		urls = append(urls, fileURL)
+	}
+	sort.Strings(urls)
+	for _, fileURL := range urls {
+		if err := deleteBlob(ctx, fileURL); err != nil {
+			return fmt.Errorf("deleting %s: %w", fileURL, err)
+		}
+	}
+
+	for _, collectionName := range models.UserDataCollections {
+		if collectionName == "auth_users" {
+			continue
+		}
+		if _, err := db.Collection(collectionName).DeleteMany(ctx, bson.M{"auth_user_id": userID}); err != nil {
+			return fmt.Errorf("deleting from %s: %w", collectionName, err)
+		}
+	}
+	_, err := db.Collection("auth_users").DeleteOne(ctx, bson.M{"auth_user_id": userID})
+	return err
+}
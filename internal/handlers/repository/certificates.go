@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"RAAS/internal/dto"
+
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var ErrInvalidCertificate = errors.New("invalid certificate")
+
+// certificateDetails validates the optional certificate fields and returns them ready to store.
+// Dates are stored as BSON dates so the expiry reminder worker can query them.
+func certificateDetails(input dto.CertificateRequest, now time.Time) (bson.M, error) {
+	details := bson.M{}
+
+	var issued, expires time.Time
+	if value := strings.TrimSpace(input.IssueDate); value != "" {
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: issue_date must be YYYY-MM-DD", ErrInvalidCertificate)
+		}
+		if t.After(now) {
+			return nil, fmt.Errorf("%w: issue_date must not be in the future", ErrInvalidCertificate)
+		}
+		issued = t
+		details["issue_date"] = t
+	}
+	if value := strings.TrimSpace(input.ExpiryDate); value != "" {
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: expiry_date must be YYYY-MM-DD", ErrInvalidCertificate)
+		}
+		if !issued.IsZero() && !t.After(issued) {
+			return nil, fmt.Errorf("%w: expiry_date must be after issue_date", ErrInvalidCertificate)
+		}
+		expires = t
+		details["expiry_date"] = expires
+	}
+
+	for field, value := range map[string]string{
+		"issuer":           input.Issuer,
+		"credential_id":    input.CredentialID,
+		"verification_url": input.VerificationURL,
+	} {
+		if value = strings.TrimSpace(value); value != "" {
+			details[field] = value
+		}
+	}
+	return details, nil
+}
+
+// CertificateExpiry returns the expiry date of a stored certificate, if it has one
+func CertificateExpiry(cert bson.M) (time.Time, bool) {
+	return ReadStoredDate(cert["expiry_date"])
+}
+
+// CertificateExpired reports whether a stored certificate is past its expiry date
+func CertificateExpired(cert bson.M, now time.Time) bool {
+	expiry, ok := CertificateExpiry(cert)
+	return ok && !now.Before(expiry)
+}
+
+// AnnotateCertificates returns copies of the certificates flagged with "expired", and
+// "expires_in_days" for the ones that still run out at some point
+func AnnotateCertificates(certificates []bson.M, now time.Time) []bson.M {
+	annotated := make([]bson.M, 0, len(certificates))
+	for _, cert := range certificates {
+		entry := bson.M{}
+		for key, value := range cert {
+			entry[key] = value
+		}
+		entry["expired"] = CertificateExpired(cert, now)
+		if expiry, ok := CertificateExpiry(cert); ok && now.Before(expiry) {
+			entry["expires_in_days"] = int(expiry.Sub(now).Hours() / 24)
+		}
+		annotated = append(annotated, entry)
+	}
+	return annotated
+}
+
+// CVCertificates drops expired certificates unless the seeker asked to include them
+func CVCertificates(certificates []bson.M, includeExpired bool, now time.Time) []bson.M {
+	if includeExpired {
+		return certificates
+	}
+	current := []bson.M{}
+	for _, cert := range certificates {
+		if !CertificateExpired(cert, now) {
+			current = append(current, cert)
+		}
+	}
+	return current
+}
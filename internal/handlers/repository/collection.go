@@ -26,6 +26,15 @@ func GetSeekerData(db *mongo.Database, userID string) (models.Seeker, []string,
 }
 
 
+// SeekerSkills returns the skills of the seeker's professional summary
+func SeekerSkills(seeker models.Seeker) []string {
+	skills := extractSkills(seeker.ProfessionalSummary)
+	if skills == nil {
+		skills = []string{}
+	}
+	return skills
+}
+
 // Extract preferred titles from seeker
 func CollectPreferredTitles(seeker models.Seeker) []string {
 	var titles []string
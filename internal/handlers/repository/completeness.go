@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"RAAS/core/config"
+	"RAAS/internal/dto"
+	"RAAS/internal/models"
+
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CompletenessRule is one weighted item of the profile completeness score.
+// The item counts once all of its predicates hold on the seeker document.
+type CompletenessRule struct {
+	Key        string                       `json:"key"`
+	Label      string                       `json:"label"`
+	Weight     int                          `json:"weight"`
+	Hint       string                       `json:"hint"`
+	Predicates []models.OnboardingPredicate `json:"predicates"`
+}
+
+// ProfileCompleteness is the score (0-100) plus the items still missing, heaviest first
+type ProfileCompleteness struct {
+	Score   int                         `json:"score"`
+	Missing []dto.ProfileCompletionItem `json:"missing"`
+}
+
+var (
+	completenessOnce  sync.Once
+	completenessRules []CompletenessRule
+)
+
+// DefaultCompletenessRules are used when PROFILE_COMPLETENESS_FILE is not set or cannot be read
+func DefaultCompletenessRules() []CompletenessRule {
+	return []CompletenessRule{
+		{Key: "first_name", Label: "First name", Weight: 10, Hint: "Add your first name in personal info",
+			Predicates: []models.OnboardingPredicate{{Field: "personal_info.first_name", Op: models.PredicateNotEmpty}}},
+		{Key: "date_of_birth", Label: "Date of birth", Weight: 5, Hint: "Add your date of birth in personal info",
+			Predicates: []models.OnboardingPredicate{{Field: "personal_info.date_of_birth", Op: models.PredicateNotEmpty}}},
+		{Key: "address", Label: "Address", Weight: 5, Hint: "Add your city or address so we can match local jobs",
+			Predicates: []models.OnboardingPredicate{{Field: "personal_info.address", Op: models.PredicateNotEmpty}}},
+		{Key: "linkedin_profile", Label: "LinkedIn profile", Weight: 5, Hint: "Link your LinkedIn profile",
+			Predicates: []models.OnboardingPredicate{{Field: "personal_info.linkedin_profile", Op: models.PredicateNotEmpty}}},
+		{Key: "about", Label: "Professional summary", Weight: 10, Hint: "Write a short summary about yourself",
+			Predicates: []models.OnboardingPredicate{{Field: "professional_summary.about", Op: models.PredicateNotEmpty}}},
+		{Key: "skills", Label: "Skills", Weight: 15, Hint: "List at least 3 skills",
+			Predicates: []models.OnboardingPredicate{{Field: "professional_summary.skills", Op: models.PredicateMinItems, Value: 3}}},
+		{Key: "work_experiences", Label: "Work experience", Weight: 15, Hint: "Add at least one work experience",
+			Predicates: []models.OnboardingPredicate{{Field: "work_experiences", Op: models.PredicateMinItems, Value: 1}}},
+		{Key: "education", Label: "Education", Weight: 10, Hint: "Add your education",
+			Predicates: []models.OnboardingPredicate{{Field: "education", Op: models.PredicateMinItems, Value: 1}}},
+		{Key: "languages", Label: "Languages", Weight: 10, Hint: "Add the languages you speak",
+			Predicates: []models.OnboardingPredicate{{Field: "languages", Op: models.PredicateMinItems, Value: 1}}},
+		{Key: "certificates", Label: "Certificates", Weight: 5, Hint: "Add a certificate to stand out",
+			Predicates: []models.OnboardingPredicate{{Field: "certificates", Op: models.PredicateMinItems, Value: 1}}},
+		{Key: "preferred_job_title", Label: "Preferred job title", Weight: 10, Hint: "Choose the job title you are looking for",
+			Predicates: []models.OnboardingPredicate{{Field: "primary_title", Op: models.PredicateNotEmpty}}},
+	}
+}
+
+// LoadCompletenessRules reads the rules from PROFILE_COMPLETENESS_FILE once, falling back to the defaults
+func LoadCompletenessRules() []CompletenessRule {
+	completenessOnce.Do(func() {
+		completenessRules = DefaultCompletenessRules()
+		if config.Cfg == nil || config.Cfg.Project.ProfileCompletenessFile == "" {
+			return
+		}
+
+		path := config.Cfg.Project.ProfileCompletenessFile
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("⚠️ Profile completeness file not loaded (%s), using defaults: %v", path, err)
+			return
+		}
+		var rules []CompletenessRule
+		if err := json.Unmarshal(data, &rules); err != nil || len(rules) == 0 {
+			log.Printf("⚠️ Invalid profile completeness file %s, using defaults: %v", path, err)
+			return
+		}
+		completenessRules = rules
+	})
+	return completenessRules
+}
+
+// CalculateProfileCompleteness scores a seeker document against the completeness rules
+func CalculateProfileCompleteness(seekerDoc bson.M) ProfileCompleteness {
+	result := ProfileCompleteness{Missing: []dto.ProfileCompletionItem{}}
+
+	total, earned := 0, 0
+	for _, rule := range LoadCompletenessRules() {
+		if rule.Weight <= 0 {
+			continue
+		}
+		total += rule.Weight
+
+		satisfied := true
+		for _, predicate := range rule.Predicates {
+			if !EvaluatePredicate(seekerDoc, predicate) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			earned += rule.Weight
+			continue
+		}
+		result.Missing = append(result.Missing, dto.ProfileCompletionItem{
+			Key:    rule.Key,
+			Label:  rule.Label,
+			Weight: rule.Weight,
+			Hint:   rule.Hint,
+		})
+	}
+
+	if total > 0 {
+		result.Score = (earned*100 + total/2) / total
+	}
+
+	// Heaviest missing items first, they are the most useful hints
+	sort.SliceStable(result.Missing, func(i, j int) bool {
+		return result.Missing[i].Weight > result.Missing[j].Weight
+	})
+	return result
+}
+
+// RefreshProfileCompletion recalculates the completeness of a seeker and stores it as "profile_completion".
+// It is called after every preference write; failures are logged and never fail the request.
+func RefreshProfileCompletion(ctx context.Context, db *mongo.Database, userID string) (ProfileCompleteness, error) {
+	collection := db.Collection("seekers")
+
+	var seekerDoc bson.M
+	if err := collection.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seekerDoc); err != nil {
+		log.Printf("Failed to load seeker for profile completion, auth_user_id: %s, Error: %v", userID, err)
+		return ProfileCompleteness{}, err
+	}
+
+	completeness := CalculateProfileCompleteness(seekerDoc)
+	if _, err := collection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": bson.M{"profile_completion": completeness.Score}}); err != nil {
+		log.Printf("Failed to store profile completion for auth_user_id: %s, Error: %v", userID, err)
+		return completeness, err
+	}
+	return completeness, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Credentials and tokens of the auth user never leave the database
+var authUserSecretFields = []string{"password", "verification_token", "reset_token", "reset_token_expiry", "two_factor_secret"}
+
+// DataExportManifest is manifest.json at the root of the archive
+type DataExportManifest struct {
+	AuthUserID   string         `json:"auth_user_id"`
+	GeneratedAt  time.Time      `json:"generated_at"`
+	Collections  map[string]int `json:"collections"` // Documents per data/<collection>.json
+	Files        []string       `json:"files"`
+	MissingFiles []string       `json:"missing_files,omitempty"` // Referenced but could not be downloaded
+}
+
+// BuildDataExportArchive collects every document of the user from models.UserDataCollections
+// into data/<collection>.json, and the uploaded files those documents reference into files/.
+// download fetches a stored file by its blob URL.
+func BuildDataExportArchive(ctx context.Context, db *mongo.Database, userID string, download func(ctx context.Context, fileURL string) ([]byte, error), now time.Time) ([]byte, error) {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	manifest := DataExportManifest{AuthUserID: userID, GeneratedAt: now, Collections: map[string]int{}, Files: []string{}}
+	fileURLs := map[string]bool{}
+
+	for _, collectionName := range models.UserDataCollections {
+		// The export bookkeeping is not user data
+		if collectionName == "data_exports" {
+			continue
+		}
+		cursor, err := db.Collection(collectionName).Find(ctx, bson.M{"auth_user_id": userID})
+		if err != nil {
+			return nil, err
+		}
+		documents := []bson.M{}
+		if err := cursor.All(ctx, &documents); err != nil {
+			return nil, err
+		}
+		if len(documents) == 0 {
+			continue
+		}
+
+		for _, doc := range documents {
+			if collectionName == "auth_users" {
+				for _, field := range authUserSecretFields {
+					delete(doc, field)
+				}
+			}
+			collectBlobURLs(doc, fileURLs)
+		}
+		if err := writeJSONEntry(archive, "data/"+collectionName+".json", documents); err != nil {
+			return nil, err
+		}
+		manifest.Collections[collectionName] = len(documents)
+	}
+
+	urls := make([]string, 0, len(fileURLs))
+	for fileURL := range fileURLs {
+		urls = append(urls, fileURL)
+	}
+	sort.Strings(urls)
+	for _, fileURL := range urls {
+		containerName, blobName, err := SplitBlobURL(fileURL)
+		if err != nil {
+			continue
+		}
+		data, err := download(ctx, fileURL)
+		if err != nil {
+			manifest.MissingFiles = append(manifest.MissingFiles, fileURL)
+			continue
+		}
+		name := path.Join("files", containerName, blobName)
+		entry, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := entry.Write(data); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, name)
+	}
+
+	if err := writeJSONEntry(archive, "manifest.json", manifest); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeJSONEntry(archive *zip.Writer, name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	entry, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(data)
+	return err
+}
+
+// collectBlobURLs walks a document and records every string pointing into our blob storage
+// (certificate files, profile photos and thumbnails, generated CVs and cover letters)
+func collectBlobURLs(value interface{}, urls map[string]bool) {
+	switch v := value.(type) {
+	case bson.M:
+		for _, item := range v {
+			collectBlobURLs(item, urls)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			collectBlobURLs(item, urls)
+		}
+	case primitive.D:
+		for _, item := range v {
+			collectBlobURLs(item.Value, urls)
+		}
+	case primitive.A:
+		for _, item := range v {
+			collectBlobURLs(item, urls)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectBlobURLs(item, urls)
+		}
+	case string:
+		if u, err := url.Parse(v); err == nil && u.Scheme == "https" && strings.HasSuffix(u.Host, ".blob.core.windows.net") {
+			urls[v] = true
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/utils"
+
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DateRangeKind selects the future-date limits applied to a profile entry
+type DateRangeKind int
+
+const (
+	WorkDateRange DateRangeKind = iota
+	EducationDateRange
+)
+
+// How far ahead profile dates may lie. Work allows signed offers and fixed-term contracts,
+// education allows expected graduation dates.
+var dateRangeLimits = map[DateRangeKind]struct{ start, end time.Duration }{
+	WorkDateRange:      {start: 90 * 24 * time.Hour, end: 2 * 365 * 24 * time.Hour},
+	EducationDateRange: {start: 365 * 24 * time.Hour, end: 7 * 365 * 24 * time.Hour},
+}
+
+// Overlaps shorter than this are notice periods or handovers, not worth a warning
+const overlapTolerance = 31 * 24 * time.Hour
+
+// NormalizeEndDate treats a missing or empty end date as "current": the entry runs until today
+func NormalizeEndDate(end *utils.DateOnly) *utils.DateOnly {
+	if end == nil || end.Time.IsZero() {
+		return nil
+	}
+	return end
+}
+
+// ValidateDateRange checks a start/end pair of a work experience or education entry.
+// A nil end date marks a current position or ongoing study.
+func ValidateDateRange(kind DateRangeKind, start utils.DateOnly, end *utils.DateOnly, now time.Time) []dto.FieldError {
+	var errs []dto.FieldError
+	limits := dateRangeLimits[kind]
+
+	if start.Time.IsZero() {
+		return append(errs, dto.FieldError{Field: "start_date", Message: "is required"})
+	}
+	if start.Time.After(now.Add(limits.start)) {
+		errs = append(errs, dto.FieldError{Field: "start_date", Message: fmt.Sprintf("must not be later than %s", now.Add(limits.start).Format("2006-01-02"))})
+	}
+
+	end = NormalizeEndDate(end)
+	if end == nil {
+		return errs
+	}
+	if end.Time.Before(start.Time) {
+		errs = append(errs, dto.FieldError{Field: "end_date", Message: "must not be before start_date"})
+	}
+	if end.Time.After(now.Add(limits.end)) {
+		errs = append(errs, dto.FieldError{Field: "end_date", Message: fmt.Sprintf("must not be later than %s", now.Add(limits.end).Format("2006-01-02"))})
+	}
+	return errs
+}
+
+// IsFullTime reports whether an employment type means full-time ("Full-time", "full time", "FULL_TIME")
+func IsFullTime(employmentType string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(employmentType))
+	return normalized == "fulltime"
+}
+
+type dateInterval struct {
+	start, end time.Time
+}
+
+// experienceInterval reads the period of a stored work experience, clamped to now.
+// Current positions run until now; entries that have not started yet are skipped.
+func experienceInterval(exp bson.M, now time.Time) (dateInterval, bool) {
+	start, ok := ReadStoredDate(exp["start_date"])
+	if !ok || start.After(now) {
+		return dateInterval{}, false
+	}
+	end, ok := ReadStoredDate(exp["end_date"])
+	if !ok || end.After(now) {
+		end = now
+	}
+	if end.Before(start) {
+		return dateInterval{}, false
+	}
+	return dateInterval{start: start, end: end}, true
+}
+
+// WorkOverlapWarnings lists the stored full-time positions a new full-time position overlaps.
+// Overlaps are allowed, the warnings only ask the seeker to double-check the dates.
+func WorkOverlapWarnings(existing []bson.M, employmentType string, start utils.DateOnly, end *utils.DateOnly) []string {
+	if !IsFullTime(employmentType) {
+		return nil
+	}
+	far := time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+	candidate := dateInterval{start: start.Time, end: far}
+	if end = NormalizeEndDate(end); end != nil {
+		candidate.end = end.Time
+	}
+
+	var warnings []string
+	for _, exp := range existing {
+		if employment, _ := exp["employment_type"].(string); !IsFullTime(employment) {
+			continue
+		}
+		other, ok := experienceInterval(exp, far)
+		if !ok {
+			continue
+		}
+		overlapStart, overlapEnd := laterOf(candidate.start, other.start), earlierOf(candidate.end, other.end)
+		if overlapEnd.Sub(overlapStart) <= overlapTolerance {
+			continue
+		}
+		title, _ := exp["job_title"].(string)
+		company, _ := exp["company_name"].(string)
+		period := FormatStoredDate(exp["start_date"]) + " to present"
+		if until := FormatStoredDate(exp["end_date"]); until != "" {
+			period = FormatStoredDate(exp["start_date"]) + " to " + until
+		}
+		warnings = append(warnings, fmt.Sprintf("Overlaps with full-time position %s at %s (%s)", title, company, period))
+	}
+	return warnings
+}
+
+// TotalExperienceMonths totals the work experience in whole months. Overlapping periods
+// (a side job next to a full-time job, back-to-back positions sharing a month) count once.
+func TotalExperienceMonths(workExperiences []bson.M, now time.Time) int {
+	var intervals []dateInterval
+	for _, exp := range workExperiences {
+		if interval, ok := experienceInterval(exp, now); ok {
+			intervals = append(intervals, interval)
+		}
+	}
+	if len(intervals) == 0 {
+		return 0
+	}
+
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start.Before(intervals[j].start) })
+
+	total := 0
+	current := intervals[0]
+	for _, interval := range intervals[1:] {
+		// Back-to-back positions (one ends on the 31st, the next starts on the 1st) are one stretch
+		if !interval.start.After(current.end.Add(24 * time.Hour)) {
+			current.end = laterOf(current.end, interval.end)
+			continue
+		}
+		total += monthsBetween(current.start, current.end)
+		current = interval
+	}
+	return total + monthsBetween(current.start, current.end)
+}
+
+// monthsBetween counts the whole calendar months from start to end
+func monthsBetween(start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
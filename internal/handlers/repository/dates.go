@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"RAAS/utils"
+
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReadStoredDate reads a date from a profile entry field. Dates written through
+// utils.DateOnly end up as {"time": <datetime>} sub-documents, older entries may
+// hold a plain datetime or a "YYYY-MM-DD" string.
+func ReadStoredDate(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, false
+	case time.Time:
+		return v, !v.IsZero()
+	case primitive.DateTime:
+		t := v.Time().UTC()
+		return t, !t.IsZero()
+	case int64:
+		return time.UnixMilli(v).UTC(), true
+	case float64:
+		return time.UnixMilli(int64(v)).UTC(), true
+	case string:
+		t, err := time.Parse("2006-01-02", v)
+		return t, err == nil
+	case utils.DateOnly:
+		return v.Time, !v.Time.IsZero()
+	case *utils.DateOnly:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return v.Time, !v.Time.IsZero()
+	case bson.M:
+		return ReadStoredDate(v["time"])
+	case map[string]interface{}:
+		return ReadStoredDate(v["time"])
+	case bson.D:
+		for _, e := range v {
+			if e.Key == "time" {
+				return ReadStoredDate(e.Value)
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// FormatStoredDate renders a stored date as YYYY-MM-DD, or "" when unset.
+func FormatStoredDate(value interface{}) string {
+	t, ok := ReadStoredDate(value)
+	if !ok {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Jobs with the same fingerprint whose description hashes differ in at most this many
+// of the 64 bits are the same opening. Unrelated descriptions differ in about 32 bits,
+// a few added sentences in a short posting already move the hash by 8.
+const nearDuplicateDistance = 10
+
+// descriptionShingleSize is the number of consecutive words hashed together
+const descriptionShingleSize = 3
+
+// Legal forms dropped from the end of a company name, "Meta Platforms, Inc." matches "Meta Platforms"
+var companyLegalForms = map[string]bool{
+	"gmbh": true, "mbh": true, "ag": true, "se": true, "kg": true, "co": true, "ug": true,
+	"inc": true, "ltd": true, "llc": true, "corp": true, "corporation": true, "plc": true,
+}
+
+// Gender markers of German postings, "Backend Developer (m/w/d)"
+var titleGenderMarker = regexp.MustCompile(`\(\s*[mwfdx]\s*(/\s*[mwfdx]\s*)+\)`)
+
+// JobFingerprint hashes the normalized company, title and city of a job
+func JobFingerprint(company, title, location string) string {
+	companyWords := normalizedWords(company)
+	for len(companyWords) > 1 && companyLegalForms[companyWords[len(companyWords)-1]] {
+		companyWords = companyWords[:len(companyWords)-1]
+	}
+	titleWords := normalizedWords(titleGenderMarker.ReplaceAllString(strings.ToLower(title), " "))
+	city, _, _ := strings.Cut(NormalizeJobLocation(location), ",")
+
+	key := strings.Join([]string{
+		strings.Join(companyWords, " "),
+		strings.Join(titleWords, " "),
+		strings.Join(normalizedWords(city), " "),
+	}, "|")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// DescriptionHash is a 64-bit SimHash over word shingles of the description.
+// Reposts with small edits get hashes a few bits apart.
+func DescriptionHash(description string) int64 {
+	words := normalizedWords(description)
+	if len(words) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	addShingle := func(shingle []string) {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(strings.Join(shingle, " ")))
+		sum := hasher.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<uint(bit)) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+	if len(words) < descriptionShingleSize {
+		addShingle(words)
+	}
+	for i := 0; i+descriptionShingleSize <= len(words); i++ {
+		addShingle(words[i : i+descriptionShingleSize])
+	}
+
+	var hash uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			hash |= 1 << uint(bit)
+		}
+	}
+	return int64(hash)
+}
+
+// SetJobFingerprint fills in the fingerprint and description hash of a job
+func SetJobFingerprint(job *models.Job) {
+	job.Fingerprint = JobFingerprint(job.Company, job.Title, job.Location)
+	job.DescriptionHash = DescriptionHash(job.JobDescription)
+}
+
+func normalizedWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func isNearDuplicate(a, b int64) bool {
+	return bits.OnesCount64(uint64(a^b)) <= nearDuplicateDistance
+}
+
+// ResolveCanonicalJob returns the job, or its canonical job when it is a duplicate.
+// An open duplicate of a closed canonical stands for itself until the group is regrouped.
+func ResolveCanonicalJob(ctx context.Context, db *mongo.Database, jobID string) (models.Job, error) {
+	var job models.Job
+	if err := db.Collection("jobs").FindOne(ctx, bson.M{"job_id": jobID}).Decode(&job); err != nil {
+		return models.Job{}, err
+	}
+	if job.CanonicalJobID == "" || job.CanonicalJobID == job.JobID {
+		return job, nil
+	}
+	var canonical models.Job
+	if err := db.Collection("jobs").FindOne(ctx, bson.M{"job_id": job.CanonicalJobID}).Decode(&canonical); err != nil {
+		// A group being regrouped can briefly point to a job that moved, fall back to the job itself
+		if err == mongo.ErrNoDocuments {
+			return job, nil
+		}
+		return models.Job{}, err
+	}
+	if JobStatus(canonical) != models.JobStatusActive && JobStatus(job) == models.JobStatusActive {
+		return job, nil
+	}
+	return canonical, nil
+}
+
+// RegroupJobs re-runs duplicate grouping for the fingerprints the given jobs belong to,
+// now or before their latest update, and returns the canonical job_id of every duplicate.
+func RegroupJobs(ctx context.Context, db *mongo.Database, jobIDs []string) (map[string]string, error) {
+	if len(jobIDs) == 0 {
+		return map[string]string{}, nil
+	}
+	jobsCollection := db.Collection("jobs")
+
+	cursor, err := jobsCollection.Find(ctx, bson.M{"$or": []bson.M{
+		{"job_id": bson.M{"$in": jobIDs}},
+		{"canonical_job_id": bson.M{"$in": jobIDs}},
+		{"source_links.job_id": bson.M{"$in": jobIDs}},
+	}}, options.Find().SetProjection(bson.M{"fingerprint": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var affected []models.Job
+	if err := cursor.All(ctx, &affected); err != nil {
+		return nil, err
+	}
+	fingerprints := map[string]bool{}
+	for _, job := range affected {
+		if job.Fingerprint != "" {
+			fingerprints[job.Fingerprint] = true
+		}
+	}
+	if len(fingerprints) == 0 {
+		return map[string]string{}, nil
+	}
+	fingerprintList := make([]string, 0, len(fingerprints))
+	for fingerprint := range fingerprints {
+		fingerprintList = append(fingerprintList, fingerprint)
+	}
+
+	// Members of a group that moved to another fingerprint keep a stale canonical_job_id,
+	// so their groups are rebuilt from scratch as well
+	cursor, err = jobsCollection.Find(ctx,
+		bson.M{"fingerprint": bson.M{"$in": fingerprintList}},
+		options.Find().SetSort(bson.D{{Key: "first_seen_at", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var jobs []models.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+
+	writes, canonicalOf := groupDuplicates(jobs, false)
+	if len(writes) > 0 {
+		if _, err := jobsCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return nil, err
+		}
+	}
+	return canonicalOf, nil
+}
+
+// DedupeAllJobs recomputes the fingerprint of every job and regroups the whole collection.
+// It returns the number of canonical jobs and of duplicates.
+func DedupeAllJobs(ctx context.Context, db *mongo.Database) (int, int, error) {
+	jobsCollection := db.Collection("jobs")
+	cursor, err := jobsCollection.Find(ctx, bson.M{},
+		options.Find().SetSort(bson.D{{Key: "first_seen_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []models.Job
+	for cursor.Next(ctx) {
+		var job models.Job
+		if err := cursor.Decode(&job); err != nil {
+			return 0, 0, err
+		}
+		SetJobFingerprint(&job)
+		jobs = append(jobs, job)
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	writes, canonicalOf := groupDuplicates(jobs, true)
+	for start := 0; start < len(writes); start += 1000 {
+		end := start + 1000
+		if end > len(writes) {
+			end = len(writes)
+		}
+		if _, err := jobsCollection.BulkWrite(ctx, writes[start:end], options.BulkWrite().SetOrdered(false)); err != nil {
+			return 0, 0, err
+		}
+	}
+	return len(jobs) - len(canonicalOf), len(canonicalOf), nil
+}
+
+// groupDuplicates assigns every job to the first earlier job with the same fingerprint and a
+// near-identical description. jobs must be in first-seen order, so the oldest posting stays canonical.
+// Open jobs are grouped before closed ones: a canonical is always open while its group has an open
+// member, otherwise closing it would hide the whole group.
+// withFingerprint also stores the fingerprint and description hash of each job.
+func groupDuplicates(jobs []models.Job, withFingerprint bool) ([]mongo.WriteModel, map[string]string) {
+	canonicalOf := map[string]string{}
+	links := map[string][]models.SourceLink{}
+	canonicals := map[string][]int{} // fingerprint -> indexes of its canonical jobs
+
+	order := make([]int, 0, len(jobs))
+	for i, job := range jobs {
+		if JobStatus(job) == models.JobStatusActive {
+			order = append(order, i)
+		}
+	}
+	for i, job := range jobs {
+		if JobStatus(job) != models.JobStatusActive {
+			order = append(order, i)
+		}
+	}
+
+	for _, i := range order {
+		job := jobs[i]
+		link := models.SourceLink{JobID: job.JobID, Source: job.Source, Link: job.Link, JobLink: job.JobLink}
+		canonicalID := ""
+		for _, c := range canonicals[job.Fingerprint] {
+			if isNearDuplicate(jobs[c].DescriptionHash, job.DescriptionHash) {
+				canonicalID = jobs[c].JobID
+				break
+			}
+		}
+		if canonicalID == "" {
+			canonicals[job.Fingerprint] = append(canonicals[job.Fingerprint], i)
+			canonicalID = job.JobID
+		} else {
+			canonicalOf[job.JobID] = canonicalID
+		}
+		links[canonicalID] = append(links[canonicalID], link)
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(jobs))
+	for _, job := range jobs {
+		set := bson.M{}
+		unset := bson.M{}
+		if withFingerprint {
+			set["fingerprint"] = job.Fingerprint
+			set["description_hash"] = job.DescriptionHash
+		}
+		if canonicalID, ok := canonicalOf[job.JobID]; ok {
+			set["canonical_job_id"] = canonicalID
+			unset["source_links"] = ""
+		} else {
+			set["source_links"] = links[job.JobID]
+			unset["canonical_job_id"] = ""
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"job_id": job.JobID}).
+			SetUpdate(bson.M{"$set": set, "$unset": unset}))
+	}
+	return writes, canonicalOf
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"math/bits"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJobFingerprint(t *testing.T) {
+	base := JobFingerprint("Meta Platforms, Inc.", "Backend Developer (m/w/d)", "München, Bayern")
+	same := []struct{ company, title, location string }{
+		{"Meta Platforms", "Backend Developer", "Munich"},
+		{"meta platforms inc", "backend  developer (w / m / d)", "Munich, Germany"},
+	}
+	for _, s := range same {
+		if got := JobFingerprint(s.company, s.title, s.location); got != base {
+			t.Errorf("JobFingerprint(%q, %q, %q) differs from the base posting", s.company, s.title, s.location)
+		}
+	}
+	different := []struct{ company, title, location string }{
+		{"Meta Platforms", "Frontend Developer", "Munich"},
+		{"Meta Platforms", "Backend Developer", "Berlin"},
+		{"Other Company", "Backend Developer", "Munich"},
+	}
+	for _, d := range different {
+		if got := JobFingerprint(d.company, d.title, d.location); got == base {
+			t.Errorf("JobFingerprint(%q, %q, %q) matches the base posting", d.company, d.title, d.location)
+		}
+	}
+}
+
+func TestDescriptionHash(t *testing.T) {
+	description := "We build reliable payment systems in Go and Kubernetes for millions of customers across Europe. " +
+		"You design services, review code, mentor colleagues and take part in the on-call rotation. " +
+		"We offer flexible hours, a learning budget, thirty days of vacation and a modern office near the station."
+	repost := strings.ToUpper(description) + " Apply now!"
+	unrelated := "Our bakery is looking for a friendly sales assistant who enjoys early mornings, " +
+		"fresh bread and talking to customers. Experience in retail is a plus but not required."
+
+	distance := func(a, b string) int {
+		return bits.OnesCount64(uint64(DescriptionHash(a) ^ DescriptionHash(b)))
+	}
+	if d := distance(description, description); d != 0 {
+		t.Errorf("same description: distance %d, want 0", d)
+	}
+	if d := distance(description, repost); d > nearDuplicateDistance {
+		t.Errorf("repost with a small edit: distance %d, want at most %d", d, nearDuplicateDistance)
+	}
+	if d := distance(description, unrelated); d <= nearDuplicateDistance {
+		t.Errorf("unrelated description: distance %d, want more than %d", d, nearDuplicateDistance)
+	}
+	if DescriptionHash("") != 0 {
+		t.Error("empty description should hash to 0")
+	}
+	if DescriptionHash("Go") == 0 {
+		t.Error("a description shorter than a shingle should still be hashed")
+	}
+}
+
+func TestGroupDuplicates(t *testing.T) {
+	job := func(id, status string, hash int64) models.Job {
+		return models.Job{JobID: id, Status: status, Fingerprint: "f", DescriptionHash: hash}
+	}
+	tests := []struct {
+		name string
+		jobs []models.Job
+		want map[string]string
+	}{
+		{
+			"oldest open posting is canonical",
+			[]models.Job{job("a", "", 0), job("b", models.JobStatusActive, 1), job("c", "", 3)},
+			map[string]string{"b": "a", "c": "a"},
+		},
+		{
+			"closed oldest posting joins an open one",
+			[]models.Job{job("a", models.JobStatusExpired, 0), job("b", "", 1), job("c", "", 3)},
+			map[string]string{"a": "b", "c": "b"},
+		},
+		{
+			"closed group keeps its oldest posting",
+			[]models.Job{job("a", models.JobStatusFilled, 0), job("b", models.JobStatusRemoved, 1)},
+			map[string]string{"b": "a"},
+		},
+		{
+			"different descriptions stay apart",
+			[]models.Job{job("a", "", 0), job("b", "", -1)},
+			map[string]string{},
+		},
+		{
+			"other fingerprints stay apart",
+			[]models.Job{job("a", "", 0), {JobID: "b", Fingerprint: "g"}},
+			map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writes, canonicalOf := groupDuplicates(tt.jobs, false)
+			if len(writes) != len(tt.jobs) {
+				t.Errorf("%d writes, want one per job", len(writes))
+			}
+			if !reflect.DeepEqual(canonicalOf, tt.want) {
+				t.Errorf("canonicalOf = %v, want %v", canonicalOf, tt.want)
+			}
+		})
+	}
+}
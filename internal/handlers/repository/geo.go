@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"math"
+	"strings"
+)
+
+type cityCoordinates struct {
+	Name     string
+	Lat, Lon float64
+}
+
+// knownCities is the small gazetteer used for radius matching. Job locations are free text,
+// so a radius search expands to the known cities nearby and matches them by name.
+var knownCities = []cityCoordinates{
+	// Germany
+	{"Berlin", 52.520, 13.405}, {"Potsdam", 52.391, 13.064}, {"Hamburg", 53.551, 9.994},
+	{"Bremen", 53.079, 8.802}, {"Hannover", 52.376, 9.732}, {"Wolfsburg", 52.423, 10.787},
+	{"Braunschweig", 52.269, 10.521}, {"Kiel", 54.323, 10.123}, {"Lübeck", 53.866, 10.687},
+	{"Rostock", 54.092, 12.099}, {"Leipzig", 51.340, 12.375}, {"Dresden", 51.050, 13.738},
+	{"Halle", 51.483, 11.970}, {"Magdeburg", 52.121, 11.628}, {"Erfurt", 50.978, 11.029},
+	{"Jena", 50.927, 11.589}, {"Cologne", 50.938, 6.960}, {"Düsseldorf", 51.228, 6.774},
+	{"Bonn", 50.737, 7.098}, {"Essen", 51.456, 7.012}, {"Dortmund", 51.514, 7.468},
+	{"Duisburg", 51.434, 6.762}, {"Bochum", 51.482, 7.216}, {"Münster", 51.961, 7.626},
+	{"Bielefeld", 52.030, 8.532}, {"Aachen", 50.776, 6.084}, {"Frankfurt", 50.110, 8.682},
+	{"Wiesbaden", 50.078, 8.240}, {"Mainz", 49.993, 8.247}, {"Darmstadt", 49.873, 8.651},
+	{"Mannheim", 49.488, 8.466}, {"Heidelberg", 49.399, 8.673}, {"Karlsruhe", 49.007, 8.404},
+	{"Stuttgart", 48.776, 9.183}, {"Ulm", 48.401, 9.988}, {"Freiburg", 47.999, 7.842},
+	{"Munich", 48.135, 11.582}, {"Augsburg", 48.371, 10.898}, {"Ingolstadt", 48.766, 11.426},
+	{"Nuremberg", 49.452, 11.077}, {"Erlangen", 49.598, 11.004}, {"Regensburg", 49.013, 12.102},
+	{"Würzburg", 49.792, 9.953}, {"Saarbrücken", 49.240, 6.997}, {"Kassel", 51.312, 9.480},
+	{"Göttingen", 51.541, 9.916},
+	// Austria and Switzerland
+	{"Vienna", 48.208, 16.373}, {"Graz", 47.070, 15.439}, {"Linz", 48.306, 14.286},
+	{"Salzburg", 47.809, 13.055}, {"Innsbruck", 47.269, 11.404}, {"Zurich", 47.377, 8.541},
+	{"Basel", 47.560, 7.589}, {"Bern", 46.948, 7.447}, {"Geneva", 46.204, 6.143},
+	{"Lausanne", 46.520, 6.633},
+	// Rest of Europe
+	{"Amsterdam", 52.368, 4.904}, {"Rotterdam", 51.924, 4.478}, {"The Hague", 52.070, 4.300},
+	{"Eindhoven", 51.441, 5.470}, {"Utrecht", 52.091, 5.122}, {"Brussels", 50.850, 4.352},
+	{"Antwerp", 51.219, 4.402}, {"Luxembourg", 49.612, 6.130}, {"Paris", 48.857, 2.352},
+	{"Lyon", 45.764, 4.836}, {"London", 51.507, -0.128}, {"Manchester", 53.481, -2.243},
+	{"Dublin", 53.350, -6.260}, {"Copenhagen", 55.676, 12.568}, {"Stockholm", 59.329, 18.069},
+	{"Oslo", 59.914, 10.752}, {"Helsinki", 60.170, 24.938}, {"Warsaw", 52.230, 21.012},
+	{"Kraków", 50.065, 19.945}, {"Wrocław", 51.108, 17.039}, {"Prague", 50.076, 14.438},
+	{"Budapest", 47.498, 19.040}, {"Madrid", 40.417, -3.704}, {"Barcelona", 41.385, 2.173},
+	{"Lisbon", 38.722, -9.139}, {"Milan", 45.464, 9.190}, {"Rome", 41.903, 12.496},
+	// Elsewhere
+	{"New York", 40.713, -74.006}, {"San Francisco", 37.775, -122.419}, {"Seattle", 47.606, -122.332},
+	{"Toronto", 43.653, -79.383}, {"Bangalore", 12.972, 77.595}, {"Mumbai", 19.076, 72.878},
+	{"Delhi", 28.704, 77.102}, {"Singapore", 1.352, 103.820}, {"Dubai", 25.205, 55.271},
+}
+
+// cityAliases maps local and alternative spellings onto the names in knownCities
+var cityAliases = map[string]string{
+	"münchen": "Munich", "muenchen": "Munich", "köln": "Cologne", "koeln": "Cologne",
+	"nürnberg": "Nuremberg", "nuernberg": "Nuremberg", "frankfurt am main": "Frankfurt",
+	"wien": "Vienna", "zürich": "Zurich", "genève": "Geneva", "den haag": "The Hague",
+	"praha": "Prague", "warszawa": "Warsaw", "lisboa": "Lisbon", "milano": "Milan",
+	"roma": "Rome", "bengaluru": "Bangalore", "new delhi": "Delhi", "duesseldorf": "Düsseldorf",
+}
+
+func findCity(name string) (cityCoordinates, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := cityAliases[key]; ok {
+		key = strings.ToLower(alias)
+	}
+	for _, city := range knownCities {
+		if strings.ToLower(city.Name) == key {
+			return city, true
+		}
+	}
+	return cityCoordinates{}, false
+}
+
+// CityNamesWithin returns the city itself plus the known cities within radiusKm, including
+// their local spellings. Unknown cities only match themselves.
+func CityNamesWithin(name string, radiusKm int) []string {
+	origin, ok := findCity(name)
+	if !ok {
+		return []string{strings.TrimSpace(name)}
+	}
+
+	names := []string{}
+	for _, city := range knownCities {
+		if city.Name == origin.Name || distanceKm(origin, city) <= float64(radiusKm) {
+			names = append(names, city.Name)
+		}
+	}
+	for alias, canonical := range cityAliases {
+		for _, name := range names {
+			if name == canonical {
+				names = append(names, alias)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// distanceKm is the great-circle distance between two cities
+func distanceKm(a, b cityCoordinates) float64 {
+	const earthRadiusKm = 6371.0
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat, dLon := (b.Lat-a.Lat)*math.Pi/180, (b.Lon-a.Lon)*math.Pi/180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	// MaxProfilePhotoBytes caps the size of an uploaded profile photo
+	MaxProfilePhotoBytes = 5 << 20
+	maxProfilePhotoSide  = 6000
+	profilePhotoQuality  = 90
+)
+
+// ProfilePhotoSizes are the square thumbnail sizes generated for every profile photo
+var ProfilePhotoSizes = []int{64, 128, 256}
+
+var (
+	ErrUnsupportedImage = errors.New("only JPEG and PNG images are supported")
+	ErrImageTooLarge    = errors.New("image dimensions are too large")
+)
+
+// ProcessedPhoto holds the re-encoded photo and its thumbnails, keyed by size
+type ProcessedPhoto struct {
+	Original   []byte
+	Thumbnails map[int][]byte
+}
+
+// DetectImageFormat identifies JPEG and PNG data by their magic bytes, whatever the file name says
+func DetectImageFormat(data []byte) string {
+	switch {
+	case len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF:
+		return "jpeg"
+	case len(data) >= 8 && bytes.Equal(data[:8], []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}):
+		return "png"
+	}
+	return ""
+}
+
+// ProcessProfilePhoto decodes an uploaded photo and re-encodes it as JPEG. Re-encoding drops
+// EXIF and any other metadata (GPS position, camera serial...) as the encoder writes pixels only,
+// so the EXIF orientation of camera photos is applied to the pixels first.
+func ProcessProfilePhoto(data []byte) (*ProcessedPhoto, error) {
+	format := DetectImageFormat(data)
+	if format == "" {
+		return nil, ErrUnsupportedImage
+	}
+
+	var decodeConfig func(r *bytes.Reader) (image.Config, error)
+	var decode func(r *bytes.Reader) (image.Image, error)
+	if format == "jpeg" {
+		decodeConfig = func(r *bytes.Reader) (image.Config, error) { return jpeg.DecodeConfig(r) }
+		decode = func(r *bytes.Reader) (image.Image, error) { return jpeg.Decode(r) }
+	} else {
+		decodeConfig = func(r *bytes.Reader) (image.Config, error) { return png.DecodeConfig(r) }
+		decode = func(r *bytes.Reader) (image.Image, error) { return png.Decode(r) }
+	}
+
+	// Check the dimensions before decoding to avoid allocating huge images
+	cfg, err := decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > maxProfilePhotoSide || cfg.Height > maxProfilePhotoSide {
+		return nil, ErrImageTooLarge
+	}
+
+	img, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	flat := flattenImage(img)
+	if format == "jpeg" {
+		flat = orientImage(flat, jpegOrientation(data))
+	}
+
+	original, err := encodeJPEG(flat)
+	if err != nil {
+		return nil, err
+	}
+
+	photo := &ProcessedPhoto{Original: original, Thumbnails: map[int][]byte{}}
+	for _, size := range ProfilePhotoSizes {
+		thumbnail, err := encodeJPEG(squareThumbnail(flat, size))
+		if err != nil {
+			return nil, err
+		}
+		photo.Thumbnails[size] = thumbnail
+	}
+	return photo, nil
+}
+
+// jpegOrientation reads the EXIF orientation (1-8) of a JPEG, 1 when there is none
+func jpegOrientation(data []byte) int {
+	// Walk the segments that precede the image data, looking for the APP1 Exif segment
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			// Fill byte
+			i++
+			continue
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD8):
+			// Markers without a length
+			i += 2
+			continue
+		case marker == 0xDA || marker == 0xD9:
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) >= 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation reads the orientation tag of the first IFD of an EXIF (TIFF) block
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		// Orientation is a SHORT stored inline in the value field
+		if order.Uint16(tiff[entry:]) != 0x0112 {
+			continue
+		}
+		if order.Uint16(tiff[entry+2:]) != 3 {
+			return 1
+		}
+		if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+			return orientation
+		}
+		return 1
+	}
+	return 1
+}
+
+// orientImage turns the stored pixels upright according to an EXIF orientation.
+// Orientations 5 to 8 swap width and height.
+func orientImage(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored
+				sx, sy = w-1-x, y
+			case 3: // rotated 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // flipped
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // rotate 90° clockwise
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotate 90° counter-clockwise
+				sx, sy = w-1-y, x
+			}
+			dst.SetRGBA(x, y, src.RGBAAt(sx, sy))
+		}
+	}
+	return dst
+}
+
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: profilePhotoQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// flattenImage draws the image on a white background, JPEG has no transparency
+func flattenImage(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			// Colors are alpha-premultiplied: composite over white
+			white := 0xffff - a
+			out.SetRGBA(x, y, color.RGBA{
+				R: uint8((r + white) >> 8),
+				G: uint8((g + white) >> 8),
+				B: uint8((b + white) >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return out
+}
+
+// squareThumbnail center-crops the image to a square and scales it to size x size.
+// Each target pixel averages the source pixels it covers (box filter), which keeps
+// downscaled photos smooth; upscaling falls back to nearest neighbour.
+func squareThumbnail(src *image.RGBA, size int) *image.RGBA {
+	bounds := src.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	offsetX := (bounds.Dx() - side) / 2
+	offsetY := (bounds.Dy() - side) / 2
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for ty := 0; ty < size; ty++ {
+		y0 := offsetY + ty*side/size
+		y1 := offsetY + (ty+1)*side/size
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for tx := 0; tx < size; tx++ {
+			x0 := offsetX + tx*side/size
+			x1 := offsetX + (tx+1)*side/size
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, n uint32
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					p := src.RGBAAt(x, y)
+					r += uint32(p.R)
+					g += uint32(p.G)
+					b += uint32(p.B)
+					n++
+				}
+			}
+			dst.SetRGBA(tx, ty, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 0xff})
+		}
+	}
+	return dst
+}
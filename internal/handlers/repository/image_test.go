@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// withOrientation inserts an APP1 Exif segment holding the orientation tag right after the SOI marker
+func withOrientation(t *testing.T, jpegData []byte, order binary.ByteOrder, orientation uint16) []byte {
+	t.Helper()
+	var tiff bytes.Buffer
+	if order == binary.LittleEndian {
+		tiff.WriteString("II")
+	} else {
+		tiff.WriteString("MM")
+	}
+	binary.Write(&tiff, order, uint16(42))
+	binary.Write(&tiff, order, uint32(8)) // IFD0 offset
+	binary.Write(&tiff, order, uint16(1)) // one entry
+	binary.Write(&tiff, order, uint16(0x0112))
+	binary.Write(&tiff, order, uint16(3)) // SHORT
+	binary.Write(&tiff, order, uint32(1)) // count
+	binary.Write(&tiff, order, orientation)
+	binary.Write(&tiff, order, uint16(0)) // value padding
+	binary.Write(&tiff, order, uint32(0)) // no next IFD
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, jpegData[:2]...)
+	out = append(out, segment...)
+	return append(out, jpegData[2:]...)
+}
+
+func encodeTestJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	plain := encodeTestJPEG(t, 8, 4)
+	if got := jpegOrientation(plain); got != 1 {
+		t.Errorf("no EXIF: orientation = %d, want 1", got)
+	}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		for orientation := uint16(1); orientation <= 8; orientation++ {
+			if got := jpegOrientation(withOrientation(t, plain, order, orientation)); got != int(orientation) {
+				t.Errorf("%v: orientation = %d, want %d", order, got, orientation)
+			}
+		}
+	}
+	if got := jpegOrientation(withOrientation(t, plain, binary.BigEndian, 9)); got != 1 {
+		t.Errorf("invalid value: orientation = %d, want 1", got)
+	}
+	if got := jpegOrientation([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF}); got != 1 {
+		t.Errorf("truncated: orientation = %d, want 1", got)
+	}
+}
+
+func TestOrientImage(t *testing.T) {
+	// 2x1 source: red on the left, blue on the right
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, red)
+	src.SetRGBA(1, 0, blue)
+
+	tests := []struct {
+		orientation int
+		w, h        int
+		first       color.RGBA // pixel at (0,0)
+	}{
+		{1, 2, 1, red},
+		{2, 2, 1, blue},
+		{3, 2, 1, blue},
+		{4, 2, 1, red},
+		{5, 1, 2, red},
+		{6, 1, 2, red},
+		{7, 1, 2, blue},
+		{8, 1, 2, blue},
+	}
+	for _, tt := range tests {
+		out := orientImage(src, tt.orientation)
+		if out.Bounds().Dx() != tt.w || out.Bounds().Dy() != tt.h {
+			t.Errorf("orientation %d: size = %dx%d, want %dx%d", tt.orientation, out.Bounds().Dx(), out.Bounds().Dy(), tt.w, tt.h)
+			continue
+		}
+		if got := out.RGBAAt(0, 0); got != tt.first {
+			t.Errorf("orientation %d: pixel (0,0) = %v, want %v", tt.orientation, got, tt.first)
+		}
+	}
+}
+
+func TestProcessProfilePhotoAppliesOrientation(t *testing.T) {
+	photo, err := ProcessProfilePhoto(withOrientation(t, encodeTestJPEG(t, 8, 4), binary.BigEndian, 6))
+	if err != nil {
+		t.Fatalf("ProcessProfilePhoto: %v", err)
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(photo.Original))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if cfg.Width != 4 || cfg.Height != 8 {
+		t.Errorf("size = %dx%d, want 4x8", cfg.Width, cfg.Height)
+	}
+	if jpegOrientation(photo.Original) != 1 {
+		t.Error("re-encoded photo still carries an orientation")
+	}
+}
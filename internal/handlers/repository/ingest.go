@@ -0,0 +1,383 @@
+package repository
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/models"
+
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	IngestInserted = "inserted"
+	IngestUpdated  = "updated"
+	IngestRejected = "rejected"
+)
+
+// ErrIngestBatchTooLarge is returned when a batch has more lines than the configured limit
+var ErrIngestBatchTooLarge = errors.New("batch exceeds the maximum number of jobs")
+
+// IngestResult is the outcome of one NDJSON line
+type IngestResult struct {
+	Line   int    `json:"line" bson:"line"`
+	JobID  string `json:"job_id,omitempty" bson:"job_id,omitempty"`
+	Status string `json:"status" bson:"status"`
+	Reason string `json:"reason,omitempty" bson:"reason,omitempty"`
+	// DuplicateOf is the canonical job when the posting duplicates one seen before
+	DuplicateOf string `json:"duplicate_of,omitempty" bson:"duplicate_of,omitempty"`
+}
+
+// ingestItem is a validated job waiting for the bulk write, with the line it came from
+type ingestItem struct {
+	line int
+	job  models.Job
+}
+
+// IngestJobs parses an NDJSON batch of dto.IngestJobRequest lines, validates and normalizes each line, and upserts
+// the valid ones by job_id in one unordered BulkWrite. Every non-empty line gets a result.
+// Fields the platform maintains (seq_id, status, grouping, counters, processed) are not part of the
+// input format and never overwritten by the scrapers.
+// Stored jobs are then grouped with their near-duplicates, see RegroupJobs, new ones get their seq_id
+// and jobs without a stated salary get an estimate, see EstimateJobSalaries.
+// expires_at follows the expiry rules; new jobs start active, and filled or removed jobs delivered
+// with a newer posting date are reopened, see ingestUpdate.
+func IngestJobs(ctx context.Context, db *mongo.Database, body []byte, maxJobs int, expiry JobExpiryRules, now time.Time) ([]IngestResult, error) {
+	var results []IngestResult
+	var decoded, items []ingestItem
+	seen := map[string]int{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		if len(results)+len(decoded) >= maxJobs {
+			return nil, fmt.Errorf("%w (%d)", ErrIngestBatchTooLarge, maxJobs)
+		}
+
+		var request dto.IngestJobRequest
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&request); err != nil {
+			results = append(results, IngestResult{Line: line, Status: IngestRejected, Reason: "invalid JSON: " + err.Error()})
+			continue
+		}
+		decoded = append(decoded, ingestItem{line: line, job: ingestedJob(request)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// The spellings of every title in the batch are looked up at once
+	batchTitles := make([]string, 0, len(decoded))
+	for _, item := range decoded {
+		batchTitles = append(batchTitles, item.job.Title)
+	}
+	titles := NormalizeJobTitles(ctx, db, batchTitles)
+
+	for _, item := range decoded {
+		job, err := normalizeIngestedJob(ctx, db, item.job, titles)
+		if err != nil {
+			results = append(results, IngestResult{Line: item.line, JobID: job.JobID, Status: IngestRejected, Reason: err.Error()})
+			continue
+		}
+		if first, ok := seen[job.JobID]; ok {
+			results = append(results, IngestResult{Line: item.line, JobID: job.JobID, Status: IngestRejected, Reason: fmt.Sprintf("duplicate job_id, already on line %d", first)})
+			continue
+		}
+		seen[job.JobID] = item.line
+		items = append(items, ingestItem{line: item.line, job: job})
+	}
+
+	if len(items) > 0 {
+		written, err := upsertIngestedJobs(ctx, db, items, expiry, now)
+		if err != nil {
+			return nil, err
+		}
+
+		var stored []string
+		for _, result := range written {
+			if result.Status != IngestRejected {
+				stored = append(stored, result.JobID)
+			}
+		}
+		// The jobs are stored either way, a failed grouping is repaired by the next batch or the admin backfill
+		canonicalOf, err := RegroupJobs(ctx, db, stored)
+		if err != nil {
+			log.Printf("Failed to group duplicate jobs: %v", err)
+		}
+		for i := range written {
+			written[i].DuplicateOf = canonicalOf[written[i].JobID]
+		}
+		// Listings number jobs that still lack a seq_id, so a failure here is not fatal either
+		if err := AssignIngestedJobSeqIDs(ctx, db, stored); err != nil {
+			log.Printf("Failed to assign job seq_ids: %v", err)
+		}
+		if err := EstimateJobSalaries(ctx, db, stored); err != nil {
+			log.Printf("Failed to estimate job salaries: %v", err)
+		}
+		results = append(results, written...)
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Line < results[j].Line })
+	return results, nil
+}
+
+// ingestedJob maps a scraper's line onto a job. Only the fields of the request are taken over,
+// the ones the platform maintains keep their zero values and are never written by upsertIngestedJobs.
+func ingestedJob(request dto.IngestJobRequest) models.Job {
+	job := models.Job{
+		JobID:          request.JobID,
+		Title:          request.Title,
+		Company:        request.Company,
+		Location:       request.Location,
+		PostedDate:     request.PostedDate,
+		Link:           request.Link,
+		JobLink:        request.JobLink,
+		Source:         request.Source,
+		JobDescription: request.JobDescription,
+		JobType:        request.JobType,
+		Skills:         request.Skills,
+		Industry:       request.Industry,
+		WorkMode:       request.WorkMode,
+	}
+	for _, requirement := range request.RequiredLanguages {
+		job.RequiredLanguages = append(job.RequiredLanguages, models.LanguageRequirement{Code: requirement.Code, Level: requirement.Level})
+	}
+	if request.Salary != nil {
+		job.Salary = &models.SalaryRange{
+			Min:      request.Salary.Min,
+			Max:      request.Salary.Max,
+			Currency: request.Salary.Currency,
+			Period:   request.Salary.Period,
+		}
+	}
+	return job
+}
+
+// normalizeIngestedJob validates the required fields, normalizes title, location,
+// skills, work mode, language requirements and salary and computes the fingerprint and seniority.
+// A salary sent by the scraper wins over one parsed from the description. titles holds the
+// spellings of the batch's titles, see NormalizeJobTitles.
+func normalizeIngestedJob(ctx context.Context, db *mongo.Database, job models.Job, titles map[string]string) (models.Job, error) {
+	job.JobID = strings.TrimSpace(job.JobID)
+	if job.JobID == "" {
+		return job, errors.New("job_id is required")
+	}
+
+	title := strings.Join(strings.Fields(job.Title), " ")
+	if title == "" {
+		return job, errors.New("title is required")
+	}
+	if spelling, ok := titles[JobTitleKey(title)]; ok {
+		title = spelling
+	}
+	job.Title = title
+
+	job.Company = strings.Join(strings.Fields(job.Company), " ")
+	if job.Company == "" {
+		return job, errors.New("company is required")
+	}
+
+	if job.Link == "" && job.JobLink == "" {
+		return job, errors.New("link or job_link is required")
+	}
+	for name, link := range map[string]string{"link": job.Link, "job_link": job.JobLink} {
+		if link == "" {
+			continue
+		}
+		if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return job, fmt.Errorf("%s must be an http(s) URL", name)
+		}
+	}
+
+	if job.PostedDate != "" {
+		if _, err := time.Parse("2006-01-02", job.PostedDate); err != nil {
+			return job, errors.New("posted_date must be YYYY-MM-DD")
+		}
+	}
+
+	job.Location = NormalizeJobLocation(job.Location)
+	job.Skills = NormalizeSkillList(ctx, db, job.Skills)
+
+	job.WorkMode = strings.ToLower(strings.TrimSpace(job.WorkMode))
+	switch job.WorkMode {
+	case "", models.WorkModeRemote, models.WorkModeHybrid, models.WorkModeOnsite:
+	default:
+		return job, fmt.Errorf("work_mode must be one of %s, %s or %s", models.WorkModeRemote, models.WorkModeHybrid, models.WorkModeOnsite)
+	}
+
+	for i, requirement := range job.RequiredLanguages {
+		code, _, ok := ResolveLanguage(requirement.Code)
+		if !ok {
+			return job, fmt.Errorf("required_languages[%d]: %w: %q", i, ErrUnknownLanguage, requirement.Code)
+		}
+		level, ok := NormalizeCEFRLevel(requirement.Level)
+		if !ok {
+			return job, fmt.Errorf("required_languages[%d]: %w: %q", i, ErrInvalidProficiency, requirement.Level)
+		}
+		job.RequiredLanguages[i] = models.LanguageRequirement{Code: code, Level: level, Rank: CEFRRank(level)}
+	}
+
+	if job.Salary != nil {
+		job.Salary = NormalizeProvidedSalary(job.Salary)
+	}
+	if job.Salary == nil {
+		job.Salary = ExtractSalary(job.JobDescription)
+	}
+	job.Seniority = JobSeniority(job.Title)
+	job.SalaryEstimate = nil
+
+	SetJobFingerprint(&job)
+	return job, nil
+}
+
+// ingestUpdate is the upsert of a delivered job. previous is the stored job's status, nil for a new job.
+// A job the link checker or an admin closed as filled or removed is reopened when it is delivered with
+// a posting date after it was closed: the board posted it again.
+func ingestUpdate(job models.Job, previous *models.Job, expiry JobExpiryRules, now time.Time) bson.M {
+	set := bson.M{
+		"title":              job.Title,
+		"title_key":          JobTitleKey(job.Title),
+		"company":            job.Company,
+		"location":           job.Location,
+		"posted_date":        job.PostedDate,
+		"link":               job.Link,
+		"source":             job.Source,
+		"job_description":    job.JobDescription,
+		"job_type":           job.JobType,
+		"skills":             job.Skills,
+		"job_link":           job.JobLink,
+		"required_languages": job.RequiredLanguages,
+		"industry":           job.Industry,
+		"work_mode":          job.WorkMode,
+		"fingerprint":        job.Fingerprint,
+		"description_hash":   job.DescriptionHash,
+		"seniority":          job.Seniority,
+		"last_seen_at":       now,
+	}
+	setOnInsert := bson.M{"selected_count": 0, "view_count": 0, "processed": false, "first_seen_at": now, "status": models.JobStatusActive}
+	// Without a posting date the job ages from when it was first seen, so only a new job gets its expiry
+	if job.PostedDate != "" {
+		set["expires_at"] = expiry.ExpiresAt(job, now)
+	} else {
+		setOnInsert["expires_at"] = expiry.ExpiresAt(job, now)
+	}
+	unset := bson.M{}
+	if job.Salary != nil {
+		set["salary"] = job.Salary
+		unset["salary_estimate"] = ""
+	} else {
+		unset["salary"] = ""
+	}
+	if previous != nil && redeliveryReopens(*previous, job) {
+		reopen := jobStatusUpdate(models.JobStatusActive, now)
+		for key, value := range reopen["$set"].(bson.M) {
+			set[key] = value
+		}
+		for key, value := range reopen["$unset"].(bson.M) {
+			unset[key] = value
+		}
+		delete(setOnInsert, "status")
+	}
+	return bson.M{
+		"$set":         set,
+		"$unset":       unset,
+		"$setOnInsert": setOnInsert,
+	}
+}
+
+// redeliveryReopens reports whether delivering job again reopens the closed stored job
+func redeliveryReopens(stored, job models.Job) bool {
+	status := JobStatus(stored)
+	if status != models.JobStatusFilled && status != models.JobStatusRemoved {
+		return false
+	}
+	if job.PostedDate == "" || stored.ClosedAt == nil {
+		return false
+	}
+	// posted_date is YYYY-MM-DD, so the dates compare as strings
+	return job.PostedDate > stored.ClosedAt.UTC().Format("2006-01-02")
+}
+
+// NormalizeJobLocation collapses whitespace and replaces a known city with its canonical name,
+// keeping any region or country after it ("München, Bayern" becomes "Munich, Bayern")
+func NormalizeJobLocation(location string) string {
+	location = strings.Join(strings.Fields(location), " ")
+	city, rest, _ := strings.Cut(location, ",")
+	if known, ok := findCity(city); ok {
+		city = known.Name
+	}
+	if rest = strings.TrimSpace(rest); rest != "" {
+		return city + ", " + rest
+	}
+	return city
+}
+
+func upsertIngestedJobs(ctx context.Context, db *mongo.Database, items []ingestItem, expiry JobExpiryRules, now time.Time) ([]IngestResult, error) {
+	jobIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		jobIDs = append(jobIDs, item.job.JobID)
+	}
+	stored, err := FetchJobStatuses(ctx, db, jobIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(items))
+	for _, item := range items {
+		var previous *models.Job
+		if job, ok := stored[item.job.JobID]; ok {
+			previous = &job
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"job_id": item.job.JobID}).
+			SetUpdate(ingestUpdate(item.job, previous, expiry, now)).
+			SetUpsert(true))
+	}
+
+	result, err := db.Collection("jobs").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	failed := map[int]string{}
+	if err != nil {
+		var bulkErr mongo.BulkWriteException
+		if !errors.As(err, &bulkErr) || len(bulkErr.WriteErrors) == 0 {
+			return nil, err
+		}
+		for _, writeErr := range bulkErr.WriteErrors {
+			failed[writeErr.Index] = writeErr.Message
+		}
+	}
+
+	results := make([]IngestResult, 0, len(items))
+	for i, item := range items {
+		status := IngestUpdated
+		if result != nil {
+			if _, inserted := result.UpsertedIDs[int64(i)]; inserted {
+				status = IngestInserted
+			}
+		}
+		if reason, ok := failed[i]; ok {
+			results = append(results, IngestResult{Line: item.line, JobID: item.job.JobID, Status: IngestRejected, Reason: reason})
+			continue
+		}
+		results = append(results, IngestResult{Line: item.line, JobID: item.job.JobID, Status: status})
+	}
+	return results, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/models"
+
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestIngestJobsRejectsPlatformFields(t *testing.T) {
+	lines := []string{
+		`{"job_id":"a","title":"Go Developer","company":"Acme","link":"https://jobs.example/a","status":"active"}`,
+		`{"job_id":"b","title":"Go Developer","company":"Acme","link":"https://jobs.example/b","seq_id":7}`,
+		`{"job_id":"c","title":"Go Developer","company":"Acme","link":"https://jobs.example/c","processed":true}`,
+		`{"job_id":"d","title":"Go Developer","company":"Acme","link":"https://jobs.example/d","canonical_job_id":"a"}`,
+		`{"job_id":"e","title":"Go Developer","company":"Acme","link":"https://jobs.example/e","view_count":1000}`,
+	}
+	// Every line is rejected while decoding, so nothing reaches the database
+	results, err := IngestJobs(context.Background(), nil, []byte(strings.Join(lines, "\n")), 10, JobExpiryRules{DefaultDays: 30}, time.Now())
+	if err != nil {
+		t.Fatalf("IngestJobs: %v", err)
+	}
+	if len(results) != len(lines) {
+		t.Fatalf("%d results, want %d", len(results), len(lines))
+	}
+	for _, result := range results {
+		if result.Status != IngestRejected || !strings.Contains(result.Reason, "unknown field") {
+			t.Errorf("line %d: %s %q, want rejected as unknown field", result.Line, result.Status, result.Reason)
+		}
+	}
+}
+
+func TestIngestedJob(t *testing.T) {
+	job := ingestedJob(dto.IngestJobRequest{
+		JobID:             "a",
+		Title:             "Go Developer",
+		Link:              "https://jobs.example/a",
+		RequiredLanguages: []dto.IngestLanguageRequirement{{Code: "de", Level: "B2"}},
+		Salary:            &dto.IngestSalary{Min: 50000, Max: 60000, Currency: "EUR", Period: "year"},
+	})
+	if job.JobID != "a" || job.Title != "Go Developer" || job.Link != "https://jobs.example/a" {
+		t.Errorf("job = %+v", job)
+	}
+	if len(job.RequiredLanguages) != 1 || job.RequiredLanguages[0] != (models.LanguageRequirement{Code: "de", Level: "B2"}) {
+		t.Errorf("required languages = %+v", job.RequiredLanguages)
+	}
+	if job.Salary == nil || *job.Salary != (models.SalaryRange{Min: 50000, Max: 60000, Currency: "EUR", Period: "year"}) {
+		t.Errorf("salary = %+v", job.Salary)
+	}
+	if job.Status != "" || job.SeqID != 0 || job.Processed || job.CanonicalJobID != "" {
+		t.Errorf("platform fields set: %+v", job)
+	}
+}
+
+func TestIngestUpdateReopensRedeliveredJobs(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	closedAt := time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)
+	rules := JobExpiryRules{DefaultDays: 30}
+	delivered := models.Job{JobID: "a", Title: "Go Developer", PostedDate: "2026-10-18"}
+
+	tests := []struct {
+		name       string
+		previous   *models.Job
+		postedDate string
+		reopen     bool
+	}{
+		{"removed job posted again", &models.Job{Status: models.JobStatusRemoved, ClosedAt: &closedAt}, "2026-10-18", true},
+		{"filled job posted again", &models.Job{Status: models.JobStatusFilled, ClosedAt: &closedAt}, "2026-10-18", true},
+		{"removed job with the old posting date", &models.Job{Status: models.JobStatusRemoved, ClosedAt: &closedAt}, "2026-10-01", false},
+		{"removed job posted the day it closed", &models.Job{Status: models.JobStatusRemoved, ClosedAt: &closedAt}, "2026-10-10", false},
+		{"removed job without posting date", &models.Job{Status: models.JobStatusRemoved, ClosedAt: &closedAt}, "", false},
+		{"expired job is left to ExpireJobs", &models.Job{Status: models.JobStatusExpired, ClosedAt: &closedAt}, "2026-10-18", false},
+		{"active job", &models.Job{}, "2026-10-18", false},
+		{"new job", nil, "2026-10-18", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := delivered
+			job.PostedDate = tt.postedDate
+			update := ingestUpdate(job, tt.previous, rules, now)
+			set := update["$set"].(bson.M)
+			unset := update["$unset"].(bson.M)
+			setOnInsert := update["$setOnInsert"].(bson.M)
+
+			_, setsStatus := set["status"]
+			if setsStatus != tt.reopen {
+				t.Fatalf("$set status = %v, want reopen %v", set["status"], tt.reopen)
+			}
+			if !tt.reopen {
+				if setOnInsert["status"] != models.JobStatusActive {
+					t.Errorf("$setOnInsert status = %v, want active", setOnInsert["status"])
+				}
+				return
+			}
+			if set["status"] != models.JobStatusActive {
+				t.Errorf("status = %v, want active", set["status"])
+			}
+			if _, ok := setOnInsert["status"]; ok {
+				t.Error("status is both set and set on insert")
+			}
+			for _, field := range []string{"closed_at", "link_failures"} {
+				if _, ok := unset[field]; !ok {
+					t.Errorf("%s is not unset", field)
+				}
+			}
+			if expiresAt, ok := set["expires_at"].(time.Time); !ok || !expiresAt.After(now) {
+				t.Errorf("expires_at = %v, want after %v", set["expires_at"], now)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SimilarJobsLimit is the number of similar jobs shown on a job's detail page
+const SimilarJobsLimit = 6
+
+// similarJobsQueryWords caps the words of the text query built from a job's title and skills
+const similarJobsQueryWords = 30
+
+// JobUserState is what a user has done with a job
+type JobUserState struct {
+	Saved                bool
+	Selected             bool
+	Hidden               bool
+	SelectedDate         *time.Time
+	ViewedLink           bool
+	CVGenerated          bool
+	CVURL                string
+	CoverLetterGenerated bool
+	CoverLetterURL       string
+}
+
+// FindJob looks a job up by job_id or by its numeric seq_id and returns its canonical job.
+// job_id is tried first, as scraped ids can be numeric as well.
+func FindJob(ctx context.Context, db *mongo.Database, id string) (models.Job, error) {
+	job, err := ResolveCanonicalJob(ctx, db, id)
+	if err != mongo.ErrNoDocuments {
+		return job, err
+	}
+	seqID, parseErr := strconv.ParseUint(id, 10, 64)
+	if parseErr != nil || seqID == 0 {
+		return models.Job{}, mongo.ErrNoDocuments
+	}
+	var numbered models.Job
+	if err := db.Collection("jobs").FindOne(ctx, bson.M{"seq_id": seqID}).Decode(&numbered); err != nil {
+		return models.Job{}, err
+	}
+	return ResolveCanonicalJob(ctx, db, numbered.JobID)
+}
+
+// FetchJobUserState reads the saved, selected and hidden state of a job and the documents generated for it
+func FetchJobUserState(ctx context.Context, db *mongo.Database, userID, jobID string) (JobUserState, error) {
+	var state JobUserState
+	filter := bson.M{"auth_user_id": userID, "job_id": jobID}
+
+	exists := func(collection string) (bool, error) {
+		count, err := db.Collection(collection).CountDocuments(ctx, filter, options.Count().SetLimit(1))
+		return count > 0, err
+	}
+	var err error
+	if state.Saved, err = exists("saved_jobs"); err != nil {
+		return state, err
+	}
+	if state.Hidden, err = exists("hidden_jobs"); err != nil {
+		return state, err
+	}
+
+	var selected models.SelectedJobApplication
+	switch err := db.Collection("selected_job_applications").FindOne(ctx, filter).Decode(&selected); err {
+	case nil:
+		state.Selected = true
+		state.SelectedDate = &selected.SelectedDate
+		state.ViewedLink = selected.ViewLink
+		state.CVGenerated = selected.CvGenerated
+		state.CoverLetterGenerated = selected.CoverLetterGenerated
+	case mongo.ErrNoDocuments:
+	default:
+		return state, err
+	}
+
+	var cv models.CV
+	switch err := db.Collection("cv").FindOne(ctx, filter).Decode(&cv); err {
+	case nil:
+		state.CVGenerated = true
+		state.CVURL = cv.CVUrl
+	case mongo.ErrNoDocuments:
+	default:
+		return state, err
+	}
+
+	var coverLetter models.CoverLetter
+	switch err := db.Collection("cover_letters").FindOne(ctx, filter).Decode(&coverLetter); err {
+	case nil:
+		state.CoverLetterGenerated = true
+		state.CoverLetterURL = coverLetter.CoverLetterURL
+	case mongo.ErrNoDocuments:
+	default:
+		return state, err
+	}
+	return state, nil
+}
+
+// RecordJobView stores a view of the job by the user and counts it on the job,
+// once per user, job and day (UTC)
+func RecordJobView(ctx context.Context, db *mongo.Database, userID, jobID string, now time.Time) error {
+	now = now.UTC()
+	day := now.Format("2006-01-02")
+	result, err := db.Collection("job_views").UpdateOne(ctx,
+		bson.M{"auth_user_id": userID, "job_id": jobID, "day": day},
+		bson.M{"$setOnInsert": models.JobView{AuthUserID: userID, JobID: jobID, Day: day, ViewedAt: now}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		// A concurrent request recorded the same view
+		if mongo.IsDuplicateKeyError(err) {
+			return nil
+		}
+		return err
+	}
+	if result.UpsertedCount == 0 {
+		return nil
+	}
+	_, err = db.Collection("jobs").UpdateOne(ctx, bson.M{"job_id": jobID}, bson.M{"$inc": bson.M{"view_count": 1}})
+	return err
+}
+
+// SimilarJobs finds active canonical jobs related to job by a text search over its title and skills,
+// ranked by relevance and then popularity. excludedJobIDs are left out, as is the job itself.
+func SimilarJobs(ctx context.Context, db *mongo.Database, job models.Job, excludedJobIDs []string, limit int) ([]models.Job, error) {
+	// Plain words only, so that hyphens and quotes in titles are not read as text search operators
+	words := uniqueStrings(normalizedWords(job.Title+" "+job.Skills), nil)
+	if len(words) == 0 {
+		return []models.Job{}, nil
+	}
+	if len(words) > similarJobsQueryWords {
+		words = words[:similarJobsQueryWords]
+	}
+
+	filter := bson.M{
+		"$text":            bson.M{"$search": strings.Join(words, " ")},
+		"job_id":           bson.M{"$nin": append([]string{job.JobID}, excludedJobIDs...)},
+		"canonical_job_id": bson.M{"$exists": false},
+		"status":           ActiveJobCondition()["status"],
+	}
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{
+			{Key: "score", Value: bson.M{"$meta": "textScore"}},
+			{Key: "selected_count", Value: -1},
+			{Key: "view_count", Value: -1},
+			{Key: "_id", Value: 1},
+		}).
+		SetLimit(int64(limit))
+
+	cursor, err := db.Collection("jobs").Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	jobs := []models.Job{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
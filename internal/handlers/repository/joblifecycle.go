@@ -0,0 +1,479 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Outcomes of a link check
+const (
+	LinkAlive   = "alive"
+	LinkFilled  = "filled"  // The page says the position is filled or closed
+	LinkRemoved = "removed" // 404, 410 or a "no longer available" page
+	LinkBlocked = "blocked" // The board refused the checker (403, 429), which says nothing about the job
+	LinkFailed  = "failed"  // No answer or a server error, the job is removed after several in a row
+)
+
+// linkCheckBodyBytes is how much of a posting page is searched for closed markers
+const linkCheckBodyBytes = 256 << 10
+
+// linkCheckUserAgent identifies the checker to the job boards
+const linkCheckUserAgent = "Mozilla/5.0 (compatible; RAAS-LinkChecker/1.0)"
+
+// linkCheckMaxRedirects caps the redirects followed for one link
+const linkCheckMaxRedirects = 10
+
+// errLinkCheckAddress is returned by the link check dialer for addresses it must not reach
+var errLinkCheckAddress = errors.New("link points to a private or local address")
+
+// linkCheckBlockedRanges are not public addresses: the links are scraped from job boards, so a
+// posting or a redirect pointing there must not make the checker probe the internal network
+var linkCheckBlockedRanges = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64, embeds an IPv4 address
+}
+
+// Phrases of job boards on postings that no longer take applications, lower case
+var (
+	filledPostingMarkers = []string{
+		"no longer accepting applications",
+		"position has been filled",
+		"this position is filled",
+		"bewerbungsfrist ist abgelaufen",
+		"stelle wurde bereits besetzt",
+		"stelle ist bereits besetzt",
+	}
+	removedPostingMarkers = []string{
+		"this job is no longer available",
+		"job posting is no longer available",
+		"this job has expired",
+		"stellenanzeige ist nicht mehr verfügbar",
+		"stelle ist nicht mehr verfügbar",
+		"diese stellenanzeige ist leider nicht mehr online",
+	}
+)
+
+// JobExpiryRules give the number of days a job stays listed after it was posted, per source
+type JobExpiryRules struct {
+	DefaultDays int
+	SourceDays  map[string]int // Lower-case source name to days
+}
+
+// NewJobExpiryRules reads "source:days" pairs separated by commas, e.g. "linkedin:30,xing:45".
+// Pairs that do not parse are logged and skipped, those sources get the default.
+func NewJobExpiryRules(defaultDays int, bySource string) JobExpiryRules {
+	rules := JobExpiryRules{DefaultDays: defaultDays, SourceDays: map[string]int{}}
+	for _, pair := range strings.Split(bySource, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		source, value, ok := strings.Cut(pair, ":")
+		days, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || err != nil || days <= 0 || strings.TrimSpace(source) == "" {
+			log.Printf("Ignoring invalid job expiry rule %q, expected source:days", pair)
+			continue
+		}
+		rules.SourceDays[strings.ToLower(strings.TrimSpace(source))] = days
+	}
+	return rules
+}
+
+// ExpiresAt is when a job leaves the feed: its posting date, or when it was first seen
+// when it has none, plus the days of its source
+func (r JobExpiryRules) ExpiresAt(job models.Job, now time.Time) time.Time {
+	base := now
+	if posted, err := time.Parse("2006-01-02", job.PostedDate); err == nil {
+		base = posted
+	} else if job.FirstSeenAt != nil {
+		base = *job.FirstSeenAt
+	}
+	days, ok := r.SourceDays[strings.ToLower(job.Source)]
+	if !ok {
+		days = r.DefaultDays
+	}
+	return base.AddDate(0, 0, days)
+}
+
+// ActiveJobCondition matches the jobs that may be listed, jobs without a status included
+func ActiveJobCondition() bson.M {
+	return bson.M{"status": bson.M{"$in": bson.A{models.JobStatusActive, nil}}}
+}
+
+// JobStatus is the status of a job, active when it has none
+func JobStatus(job models.Job) string {
+	if job.Status == "" {
+		return models.JobStatusActive
+	}
+	return job.Status
+}
+
+// ValidJobStatus reports whether value is one of the models.JobStatus* values
+func ValidJobStatus(value string) bool {
+	switch value {
+	case models.JobStatusActive, models.JobStatusExpired, models.JobStatusFilled, models.JobStatusRemoved:
+		return true
+	}
+	return false
+}
+
+// jobStatusUpdate sets a status, with closed_at for the closed ones
+func jobStatusUpdate(status string, now time.Time) bson.M {
+	if status == models.JobStatusActive {
+		return bson.M{"$set": bson.M{"status": status}, "$unset": bson.M{"closed_at": "", "link_failures": ""}}
+	}
+	return bson.M{"$set": bson.M{"status": status, "closed_at": now}}
+}
+
+// SetJobStatus changes the status of a job, e.g. when an admin marks it filled. expires_at is moved
+// along so that ExpireJobs keeps the change: an expired job expires now, and a job reopened
+// past its expiry gets the default number of days from now. The job's group is regrouped, so that
+// its canonical job is an open one.
+func SetJobStatus(ctx context.Context, db *mongo.Database, jobID, status string, rules JobExpiryRules, now time.Time) error {
+	jobsCollection := db.Collection("jobs")
+	var job models.Job
+	err := jobsCollection.FindOne(ctx, bson.M{"job_id": jobID},
+		options.FindOne().SetProjection(bson.M{"expires_at": 1})).Decode(&job)
+	if err != nil {
+		return err
+	}
+
+	update := jobStatusUpdate(status, now)
+	set := update["$set"].(bson.M)
+	switch {
+	case status == models.JobStatusExpired:
+		set["expires_at"] = now
+	case status == models.JobStatusActive && (job.ExpiresAt == nil || !job.ExpiresAt.After(now)):
+		set["expires_at"] = now.AddDate(0, 0, rules.DefaultDays)
+	}
+	if _, err = jobsCollection.UpdateOne(ctx, bson.M{"job_id": jobID}, update); err != nil {
+		return err
+	}
+	_, err = RegroupJobs(ctx, db, []string{jobID})
+	return err
+}
+
+// FetchJobStatuses returns the status and closed_at of the given jobs by job_id.
+// Jobs that no longer exist are missing from the map.
+func FetchJobStatuses(ctx context.Context, db *mongo.Database, jobIDs []string) (map[string]models.Job, error) {
+	statuses := map[string]models.Job{}
+	if len(jobIDs) == 0 {
+		return statuses, nil
+	}
+	cursor, err := db.Collection("jobs").Find(ctx, bson.M{"job_id": bson.M{"$in": jobIDs}},
+		options.Find().SetProjection(bson.M{"job_id": 1, "status": 1, "closed_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var jobs []models.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		statuses[job.JobID] = job
+	}
+	return statuses, nil
+}
+
+// AssignJobExpiry sets expires_at on up to limit jobs that have none, jobs stored before expiry existed
+func AssignJobExpiry(ctx context.Context, db *mongo.Database, rules JobExpiryRules, now time.Time, limit int) (int, error) {
+	jobsCollection := db.Collection("jobs")
+	cursor, err := jobsCollection.Find(ctx, bson.M{"expires_at": bson.M{"$exists": false}},
+		options.Find().
+			SetProjection(bson.M{"job_id": 1, "source": 1, "posted_date": 1, "first_seen_at": 1}).
+			SetLimit(int64(limit)))
+	if err != nil {
+		return 0, err
+	}
+	var jobs []models.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return 0, err
+	}
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(jobs))
+	for _, job := range jobs {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"job_id": job.JobID, "expires_at": bson.M{"$exists": false}}).
+			SetUpdate(bson.M{"$set": bson.M{"expires_at": rules.ExpiresAt(job, now)}}))
+	}
+	if _, err := jobsCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return 0, err
+	}
+	return len(jobs), nil
+}
+
+// ExpireJobs closes the active jobs past their expires_at and reopens expired jobs whose
+// expires_at moved into the future, because a scraper delivered them again with a newer posting date.
+// Groups whose canonical job expired are regrouped around an open member.
+// It returns the number of expired and reopened jobs.
+func ExpireJobs(ctx context.Context, db *mongo.Database, now time.Time) (int64, int64, error) {
+	jobsCollection := db.Collection("jobs")
+	expiring := bson.M{"$and": []bson.M{ActiveJobCondition(), {"expires_at": bson.M{"$lte": now}}}}
+
+	// Canonical jobs with duplicates, a second source link means the group has another member
+	canonicals, err := jobsCollection.Distinct(ctx, "job_id",
+		bson.M{"$and": []bson.M{expiring, {"source_links.1": bson.M{"$exists": true}}}})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	expired, err := jobsCollection.UpdateMany(ctx, expiring, jobStatusUpdate(models.JobStatusExpired, now))
+	if err != nil {
+		return 0, 0, err
+	}
+	regroupIDs := make([]string, 0, len(canonicals))
+	for _, id := range canonicals {
+		if jobID, ok := id.(string); ok {
+			regroupIDs = append(regroupIDs, jobID)
+		}
+	}
+	if _, err := RegroupJobs(ctx, db, regroupIDs); err != nil {
+		return expired.ModifiedCount, 0, err
+	}
+
+	reopened, err := jobsCollection.UpdateMany(ctx,
+		bson.M{"status": models.JobStatusExpired, "expires_at": bson.M{"$gt": now}},
+		jobStatusUpdate(models.JobStatusActive, now))
+	if err != nil {
+		return expired.ModifiedCount, 0, err
+	}
+	return expired.ModifiedCount, reopened.ModifiedCount, nil
+}
+
+// PublicAddress is the address policy of the link checker in production: it reports whether ip is a
+// public address the checker may connect to
+func PublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range linkCheckBlockedRanges {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewLinkCheckClient builds the client of the link checker. Its dialer only connects to the addresses
+// allow accepts, checked after name resolution, so with PublicAddress neither a link, a redirect nor
+// a DNS name resolving to an internal address reaches the internal network. Tests against a local stub
+// pass a policy that also accepts loopback. Proxies from the environment are not used, they would
+// dial in the checker's place.
+func NewLinkCheckClient(timeout time.Duration, allow func(netip.Addr) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !allow(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", errLinkCheckAddress, address)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			if len(via) >= linkCheckMaxRedirects {
+				return errors.New("too many redirects")
+			}
+			if request.URL.Scheme != "http" && request.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme %q", request.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// CheckJobLink requests a posting and tells whether it is still open. Redirects are followed
+// as the client does; the page body is searched for the closed markers of the job boards.
+func CheckJobLink(ctx context.Context, client *http.Client, link string) string {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return LinkRemoved
+	}
+	request.Header.Set("User-Agent", linkCheckUserAgent)
+	request.Header.Set("Accept", "text/html,*/*")
+
+	response, err := client.Do(request)
+	if err != nil {
+		// A link to the internal network says nothing about the job, it is left to expire
+		if errors.Is(err, errLinkCheckAddress) {
+			return LinkBlocked
+		}
+		return LinkFailed
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusGone:
+		return LinkRemoved
+	case response.StatusCode == http.StatusForbidden || response.StatusCode == http.StatusTooManyRequests ||
+		response.StatusCode == http.StatusUnauthorized:
+		return LinkBlocked
+	case response.StatusCode >= 500:
+		return LinkFailed
+	case response.StatusCode >= 400:
+		return LinkRemoved
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, linkCheckBodyBytes))
+	if err != nil {
+		return LinkFailed
+	}
+	page := strings.ToLower(string(body))
+	for _, marker := range filledPostingMarkers {
+		if strings.Contains(page, marker) {
+			return LinkFilled
+		}
+	}
+	for _, marker := range removedPostingMarkers {
+		if strings.Contains(page, marker) {
+			return LinkRemoved
+		}
+	}
+	return LinkAlive
+}
+
+// JobLinkCheck configures CheckJobLinks
+type JobLinkCheck struct {
+	Client       *http.Client
+	BatchSize    int           // Links checked per run
+	RecheckAfter time.Duration // Time between two checks of the same job
+	MaxFailures  int           // Checks in a row without an answer before the job counts as removed
+}
+
+// linkCheckUpdate is the update of a job after a check with the given result, and whether it closes
+// the job. Failures are counted and only close the job after MaxFailures in a row.
+func linkCheckUpdate(job models.Job, result string, maxFailures int, now time.Time) (bson.M, bool) {
+	set := bson.M{"link_checked_at": now}
+	unset := bson.M{}
+	closed := false
+	switch result {
+	case LinkAlive:
+		unset["link_failures"] = ""
+	case LinkBlocked:
+	case LinkFailed:
+		if job.LinkFailures+1 < maxFailures {
+			set["link_failures"] = job.LinkFailures + 1
+			break
+		}
+		result = LinkRemoved
+		fallthrough
+	default:
+		status := models.JobStatusRemoved
+		if result == LinkFilled {
+			status = models.JobStatusFilled
+		}
+		set["status"] = status
+		set["closed_at"] = now
+		unset["link_failures"] = ""
+		closed = true
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update, closed
+}
+
+// CheckJobLinks checks the links of the active jobs that were never checked or not for RecheckAfter,
+// longest unchecked first, and closes the jobs whose posting is filled or gone. Jobs without any link
+// are not checked, they are left to expire. The application link is checked, the board link when
+// there is none. Groups whose canonical job closed are regrouped around an open member.
+// It returns the number of checked and closed jobs.
+func CheckJobLinks(ctx context.Context, db *mongo.Database, check JobLinkCheck, now time.Time) (int, int, error) {
+	jobsCollection := db.Collection("jobs")
+	cursor, err := jobsCollection.Find(ctx,
+		bson.M{"$and": []bson.M{
+			ActiveJobCondition(),
+			{"$or": []bson.M{
+				{"link_checked_at": bson.M{"$exists": false}},
+				{"link_checked_at": bson.M{"$lte": now.Add(-check.RecheckAfter)}},
+			}},
+			{"$or": []bson.M{
+				{"job_link": bson.M{"$nin": bson.A{nil, ""}}},
+				{"link": bson.M{"$nin": bson.A{nil, ""}}},
+			}},
+		}},
+		options.Find().
+			SetProjection(bson.M{"job_id": 1, "link": 1, "job_link": 1, "link_failures": 1}).
+			SetSort(bson.D{{Key: "link_checked_at", Value: 1}, {Key: "_id", Value: 1}}).
+			SetLimit(int64(check.BatchSize)))
+	if err != nil {
+		return 0, 0, err
+	}
+	var jobs []models.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return 0, 0, err
+	}
+
+	writes, closedIDs := checkJobLinkBatch(ctx, check, jobs, now)
+	checked := len(writes)
+	if len(writes) > 0 {
+		if _, err := jobsCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+			return checked, 0, err
+		}
+	}
+	if _, err := RegroupJobs(ctx, db, closedIDs); err != nil {
+		return checked, len(closedIDs), err
+	}
+	return checked, len(closedIDs), nil
+}
+
+// checkJobLinkBatch checks the links of jobs and returns the update of every checked job and the
+// job_ids it closes. It stops early when ctx is done, the remaining jobs stay unchecked.
+func checkJobLinkBatch(ctx context.Context, check JobLinkCheck, jobs []models.Job, now time.Time) ([]mongo.WriteModel, []string) {
+	closedIDs := []string{}
+	writes := make([]mongo.WriteModel, 0, len(jobs))
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		link := job.JobLink
+		if link == "" {
+			link = job.Link
+		}
+		update, closed := linkCheckUpdate(job, CheckJobLink(ctx, check.Client, link), check.MaxFailures, now)
+		if closed {
+			closedIDs = append(closedIDs, job.JobID)
+		}
+
+		// A job closed or reopened meanwhile keeps that status
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"$and": []bson.M{{"job_id": job.JobID}, ActiveJobCondition()}}).
+			SetUpdate(update))
+	}
+	return writes, closedIDs
+}
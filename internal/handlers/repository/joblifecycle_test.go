@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCheckJobLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"open posting", http.StatusOK, "<h1>Backend Developer</h1><p>Apply now</p>", LinkAlive},
+		{"not found", http.StatusNotFound, "", LinkRemoved},
+		{"gone", http.StatusGone, "", LinkRemoved},
+		{"other client error", http.StatusBadRequest, "", LinkRemoved},
+		{"forbidden", http.StatusForbidden, "", LinkBlocked},
+		{"rate limited", http.StatusTooManyRequests, "", LinkBlocked},
+		{"server error", http.StatusBadGateway, "", LinkFailed},
+		{"filled marker", http.StatusOK, "<p>This Position Has Been Filled.</p>", LinkFilled},
+		{"german filled marker", http.StatusOK, "Die Stelle wurde bereits besetzt", LinkFilled},
+		{"removed marker", http.StatusOK, "<div>This job is no longer available</div>", LinkRemoved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("User-Agent") != linkCheckUserAgent {
+					t.Errorf("User-Agent = %q", r.Header.Get("User-Agent"))
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			if got := CheckJobLink(context.Background(), server.Client(), server.URL); got != tt.want {
+				t.Errorf("CheckJobLink = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckJobLinkFollowsRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/job/1" {
+			http.Redirect(w, r, "/closed", http.StatusFound)
+			return
+		}
+		w.Write([]byte("Diese Stellenanzeige ist leider nicht mehr online"))
+	}))
+	defer server.Close()
+
+	if got := CheckJobLink(context.Background(), server.Client(), server.URL+"/job/1"); got != LinkRemoved {
+		t.Errorf("CheckJobLink = %q, want %q", got, LinkRemoved)
+	}
+}
+
+func TestLinkCheckClientRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the checker reached a loopback address")
+	}))
+	defer server.Close()
+
+	client := NewLinkCheckClient(time.Second, PublicAddress)
+	if got := CheckJobLink(context.Background(), client, server.URL); got != LinkBlocked {
+		t.Errorf("loopback link: CheckJobLink = %q, want %q", got, LinkBlocked)
+	}
+
+	if got := CheckJobLink(context.Background(), client, "http://localhost:1/"); got != LinkBlocked {
+		t.Errorf("localhost link: CheckJobLink = %q, want %q", got, LinkBlocked)
+	}
+
+	// A public board redirecting to the internal network: the board is stubbed, the redirect
+	// target is dialed by the checker's own transport
+	board := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, server.URL, http.StatusFound)
+	}))
+	defer board.Close()
+	boardURL, _ := url.Parse(board.URL)
+	safe := client.Transport
+	client.Transport = roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if request.URL.Host == "jobs.example" {
+			request.URL.Host = boardURL.Host
+			return http.DefaultTransport.RoundTrip(request)
+		}
+		return safe.RoundTrip(request)
+	})
+	if got := CheckJobLink(context.Background(), client, "http://jobs.example/job/1"); got != LinkBlocked {
+		t.Errorf("redirect to loopback: CheckJobLink = %q, want %q", got, LinkBlocked)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func TestPublicAddress(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.10":     false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"::ffff:127.0.0.1": false,
+		"::ffff:10.0.0.1":  false,
+		"224.0.0.1":        false,
+	}
+	for address, want := range tests {
+		if got := PublicAddress(netip.MustParseAddr(address)); got != want {
+			t.Errorf("PublicAddress(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestLinkCheckUpdate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	const maxFailures = 3
+
+	tests := []struct {
+		name        string
+		failures    int
+		result      string
+		wantClosed  bool
+		wantStatus  string
+		wantFailure int // link_failures set by the update, 0 when not set
+		wantReset   bool
+	}{
+		{"alive resets failures", 2, LinkAlive, false, "", 0, true},
+		{"blocked keeps failures", 2, LinkBlocked, false, "", 0, false},
+		{"first failure is counted", 0, LinkFailed, false, "", 1, false},
+		{"failures below the limit are counted", 1, LinkFailed, false, "", 2, false},
+		{"failure reaching the limit removes", 2, LinkFailed, true, models.JobStatusRemoved, 0, true},
+		{"removed closes", 0, LinkRemoved, true, models.JobStatusRemoved, 0, true},
+		{"filled closes as filled", 1, LinkFilled, true, models.JobStatusFilled, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, closed := linkCheckUpdate(models.Job{LinkFailures: tt.failures}, tt.result, maxFailures, now)
+			if closed != tt.wantClosed {
+				t.Errorf("closed = %v, want %v", closed, tt.wantClosed)
+			}
+			set := update["$set"].(bson.M)
+			if set["link_checked_at"] != now {
+				t.Errorf("link_checked_at = %v, want %v", set["link_checked_at"], now)
+			}
+			status, _ := set["status"].(string)
+			if status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", status, tt.wantStatus)
+			}
+			if tt.wantClosed && set["closed_at"] != now {
+				t.Errorf("closed_at = %v, want %v", set["closed_at"], now)
+			}
+			failures, _ := set["link_failures"].(int)
+			if failures != tt.wantFailure {
+				t.Errorf("link_failures = %d, want %d", failures, tt.wantFailure)
+			}
+			unset, _ := update["$unset"].(bson.M)
+			if _, reset := unset["link_failures"]; reset != tt.wantReset {
+				t.Errorf("link_failures unset = %v, want %v", reset, tt.wantReset)
+			}
+		})
+	}
+
+	// Repeated server errors close the job on the MaxFailures-th check in a row
+	job := models.Job{}
+	for check := 1; check <= maxFailures; check++ {
+		update, closed := linkCheckUpdate(job, LinkFailed, maxFailures, now)
+		if closed != (check == maxFailures) {
+			t.Fatalf("check %d: closed = %v", check, closed)
+		}
+		if !closed {
+			job.LinkFailures = update["$set"].(bson.M)["link_failures"].(int)
+		}
+	}
+}
+
+// allowLoopback is the address policy of the tests: public addresses and the local stubs
+func allowLoopback(ip netip.Addr) bool {
+	return ip.Unmap().IsLoopback() || PublicAddress(ip)
+}
+
+func TestCheckJobLinkBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/open":
+			w.Write([]byte("<h1>Go Developer</h1>"))
+		case "/filled":
+			w.Write([]byte("We are no longer accepting applications for this role"))
+		case "/blocked":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	check := JobLinkCheck{Client: NewLinkCheckClient(time.Second, allowLoopback), MaxFailures: 3}
+	jobs := []models.Job{
+		{JobID: "open", JobLink: server.URL + "/open", LinkFailures: 1},
+		{JobID: "filled", Link: server.URL + "/filled"},
+		{JobID: "gone", JobLink: server.URL + "/gone", Link: server.URL + "/open"},
+		{JobID: "blocked", JobLink: server.URL + "/blocked"},
+		{JobID: "down-once", JobLink: server.URL + "/down"},
+		{JobID: "down-again", JobLink: server.URL + "/down", LinkFailures: 2},
+	}
+
+	writes, closedIDs := checkJobLinkBatch(context.Background(), check, jobs, now)
+	if len(writes) != len(jobs) {
+		t.Fatalf("%d writes, want %d", len(writes), len(jobs))
+	}
+	if want := []string{"filled", "gone", "down-again"}; !reflect.DeepEqual(closedIDs, want) {
+		t.Errorf("closed = %v, want %v", closedIDs, want)
+	}
+
+	want := map[string]struct {
+		status   string
+		failures int
+	}{
+		"open":       {"", 0},
+		"filled":     {models.JobStatusFilled, 0},
+		"gone":       {models.JobStatusRemoved, 0},
+		"blocked":    {"", 0},
+		"down-once":  {"", 1},
+		"down-again": {models.JobStatusRemoved, 0},
+	}
+	for i, write := range writes {
+		model := write.(*mongo.UpdateOneModel)
+		set := model.Update.(bson.M)["$set"].(bson.M)
+		status, _ := set["status"].(string)
+		failures, _ := set["link_failures"].(int)
+		if expected := want[jobs[i].JobID]; status != expected.status || failures != expected.failures {
+			t.Errorf("%s: status %q, link_failures %d, want %q, %d", jobs[i].JobID, status, failures, expected.status, expected.failures)
+		}
+		// Only active jobs are updated, a job closed meanwhile keeps its status
+		if _, ok := model.Filter.(bson.M)["$and"]; !ok {
+			t.Errorf("%s: filter %v does not require an active job", jobs[i].JobID, model.Filter)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/models"
+
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// employmentTypePatterns match the free-text job_type of scraped jobs ("Full-time", "Contract", ...)
+var employmentTypePatterns = map[string]string{
+	models.EmploymentFullTime:   `^full[\s_-]?time`,
+	models.EmploymentPartTime:   `^part[\s_-]?time`,
+	models.EmploymentContract:   `contract`,
+	models.EmploymentTemporary:  `^temp`,
+	models.EmploymentInternship: `intern`,
+	models.EmploymentFreelance:  `freelance`,
+}
+
+// NormalizeJobPreferences validates the request and returns the preferences to store
+func NormalizeJobPreferences(input dto.JobPreferencesRequest) (models.JobPreferences, error) {
+	prefs := models.JobPreferences{
+		Locations:         []models.PreferredLocation{},
+		WorkModes:         uniqueStrings(input.WorkModes, strings.ToLower),
+		MinSalary:         input.MinSalary,
+		EmploymentTypes:   uniqueStrings(input.EmploymentTypes, strings.ToLower),
+		Languages:         []string{},
+		Industries:        uniqueStrings(input.Industries, nil),
+		ExcludedCompanies: uniqueStrings(input.ExcludedCompanies, nil),
+		UpdatedAt:         time.Now(),
+	}
+
+	for _, location := range input.Locations {
+		city := strings.TrimSpace(location.City)
+		if city == "" {
+			return models.JobPreferences{}, fmt.Errorf("locations: city is required")
+		}
+		if known, ok := findCity(city); ok {
+			city = known.Name
+		}
+		prefs.Locations = append(prefs.Locations, models.PreferredLocation{City: city, RadiusKm: location.RadiusKm})
+	}
+
+	for _, language := range input.Languages {
+		code, _, ok := ResolveLanguage(language)
+		if !ok {
+			return models.JobPreferences{}, fmt.Errorf("%w: %q", ErrUnknownLanguage, language)
+		}
+		prefs.Languages = uniqueStrings(append(prefs.Languages, code), nil)
+	}
+
+	return prefs, nil
+}
+
+// JobPreferenceConditions turns the seeker's preferences into job filter conditions, one per preference.
+// Jobs that do not state a field (no work mode, industry or salary) are kept rather than hidden.
+func JobPreferenceConditions(prefs *models.JobPreferences) []bson.M {
+	if prefs == nil {
+		return nil
+	}
+	var conditions []bson.M
+
+	if condition := locationCondition(prefs); condition != nil {
+		conditions = append(conditions, condition)
+	}
+
+	if len(prefs.EmploymentTypes) > 0 {
+		var patterns bson.A
+		for _, employmentType := range prefs.EmploymentTypes {
+			if pattern, ok := employmentTypePatterns[employmentType]; ok {
+				patterns = append(patterns, primitive.Regex{Pattern: pattern, Options: "i"})
+			}
+		}
+		conditions = append(conditions, bson.M{"job_type": bson.M{"$in": append(patterns, "", nil)}})
+	}
+
+	if prefs.MinSalary > 0 {
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"salary.max": bson.M{"$exists": false}},
+			{"salary.max": bson.M{"$gte": prefs.MinSalary}},
+		}})
+	}
+
+	if len(prefs.Languages) > 0 {
+		// Every language the job asks for must be one the seeker wants to work in
+		conditions = append(conditions, bson.M{"required_languages": bson.M{"$not": bson.M{
+			"$elemMatch": bson.M{"code": bson.M{"$nin": prefs.Languages}},
+		}}})
+	}
+
+	if len(prefs.Industries) > 0 {
+		conditions = append(conditions, bson.M{"industry": bson.M{"$in": append(exactMatchPatterns(prefs.Industries), "", nil)}})
+	}
+
+	if len(prefs.ExcludedCompanies) > 0 {
+		conditions = append(conditions, bson.M{"company": bson.M{"$nin": exactMatchPatterns(prefs.ExcludedCompanies)}})
+	}
+
+	return conditions
+}
+
+// locationCondition combines the work modes with the preferred locations. Remote jobs match
+// anywhere; hybrid and onsite jobs have to be within the radius of a preferred city.
+func locationCondition(prefs *models.JobPreferences) bson.M {
+	modes := map[string]bool{}
+	for _, mode := range prefs.WorkModes {
+		modes[mode] = true
+	}
+	anyMode := len(modes) == 0
+
+	var local []bson.M
+	if len(prefs.Locations) > 0 {
+		var patterns bson.A
+		for _, location := range prefs.Locations {
+			for _, name := range CityNamesWithin(location.City, location.RadiusKm) {
+				patterns = append(patterns, primitive.Regex{Pattern: `\b` + regexp.QuoteMeta(name) + `\b`, Options: "i"})
+			}
+		}
+		local = append(local, bson.M{"location": bson.M{"$in": patterns}})
+	}
+	if !anyMode {
+		var localModes bson.A
+		for _, mode := range []string{models.WorkModeHybrid, models.WorkModeOnsite} {
+			if modes[mode] {
+				localModes = append(localModes, mode)
+			}
+		}
+		if len(localModes) == 0 {
+			local = nil
+		} else {
+			local = append(local, bson.M{"work_mode": bson.M{"$in": append(localModes, "", nil)}})
+			if !modes[models.WorkModeRemote] {
+				local = append(local, bson.M{"location": bson.M{"$not": primitive.Regex{Pattern: `remote`, Options: "i"}}})
+			}
+		}
+	}
+
+	remote := bson.M{"$or": []bson.M{
+		{"work_mode": models.WorkModeRemote},
+		{"location": primitive.Regex{Pattern: `remote`, Options: "i"}},
+	}}
+
+	switch {
+	case anyMode && len(prefs.Locations) == 0:
+		return nil
+	case anyMode || modes[models.WorkModeRemote]:
+		if local == nil {
+			return remote
+		}
+		return bson.M{"$or": []bson.M{remote, {"$and": local}}}
+	case len(local) == 0:
+		return nil
+	default:
+		return bson.M{"$and": local}
+	}
+}
+
+func exactMatchPatterns(values []string) bson.A {
+	patterns := bson.A{}
+	for _, value := range values {
+		patterns = append(patterns, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(value) + "$", Options: "i"})
+	}
+	return patterns
+}
+
+// uniqueStrings trims, drops blanks and case-insensitive duplicates, optionally transforming each value
+func uniqueStrings(values []string, transform func(string) string) []string {
+	result := []string{}
+	seen := map[string]bool{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if transform != nil {
+			value = transform(value)
+		}
+		if value == "" || seen[strings.ToLower(value)] {
+			continue
+		}
+		seen[strings.ToLower(value)] = true
+		result = append(result, value)
+	}
+	return result
+}
@@ -3,8 +3,18 @@ package repository
 import (
 	"RAAS/internal/models"
 
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -27,18 +37,302 @@ func FetchAppliedJobIDs(c *gin.Context, col *mongo.Collection, userID string) ([
 	return jobIDs, nil
 }
 
+// FetchHiddenJobIDs returns the jobs the user hid from the feed
+func FetchHiddenJobIDs(c *gin.Context, col *mongo.Collection, userID string) ([]string, error) {
+	jobIDs := []string{}
+
+	cursor, err := col.Find(c, bson.M{"auth_user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(c)
+
+	for cursor.Next(c) {
+		var hidden models.HiddenJob
+		if err := cursor.Decode(&hidden); err == nil {
+			jobIDs = append(jobIDs, hidden.JobID)
+		}
+	}
+	return jobIDs, nil
+}
+
+// Feed sort orders for ?sort=
+const (
+	JobSortMatch   = "match"   // Share of the job's skills the seeker has
+	JobSortPosted  = "posted"  // Newest first
+	JobSortPopular = "popular" // Most selected first, then most viewed
+)
+
 // Construct the job query filter
-func BuildJobFilter(preferredTitles, appliedJobIDs []string) bson.M {
-	var titleConditions []bson.M
+// JobFilterOptions narrows the job feed beyond the preferred titles
+type JobFilterOptions struct {
+	// LanguageLevels keeps jobs whose language requirements the seeker meets,
+	// e.g. "German B2" drops jobs that ask for German above B2
+	LanguageLevels []models.LanguageRequirement
+	// Preferences are the seeker's saved job-search preferences, nil when not set
+	Preferences *models.JobPreferences
+
+	// Filters from the query string, see ParseJobQueryFilters
+	Locations         []string // Cities, widened by RadiusKm
+	RadiusKm          int
+	EmploymentTypes   []string // models.Employment* values
+	Companies         []string
+	ExcludedCompanies []string
+	PostedWithinDays  int
+	SalaryMin         int
+	SalaryMax         int
+	Remote            *bool
+	RequiredLanguages []string // ISO 639-1 codes the job has to ask for
+}
+
+// ParseJobQueryFilters reads the feed filters from the query string:
+// location (repeatable) with radius_km, job_type, company, exclude_company, required_language (repeatable),
+// posted_within (days), salary_min, salary_max and remote (true or false)
+func ParseJobQueryFilters(query url.Values, opts *JobFilterOptions) error {
+	positiveInt := func(name string) (int, error) {
+		value := query.Get(name)
+		if value == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%s must be a non-negative number", name)
+		}
+		return n, nil
+	}
+
+	var err error
+	opts.Locations = uniqueStrings(query["location"], nil)
+	if opts.RadiusKm, err = positiveInt("radius_km"); err != nil {
+		return err
+	}
+	if opts.PostedWithinDays, err = positiveInt("posted_within"); err != nil {
+		return err
+	}
+	if opts.SalaryMin, err = positiveInt("salary_min"); err != nil {
+		return err
+	}
+	if opts.SalaryMax, err = positiveInt("salary_max"); err != nil {
+		return err
+	}
+	if opts.SalaryMax > 0 && opts.SalaryMax < opts.SalaryMin {
+		return errors.New("salary_max must not be below salary_min")
+	}
+
+	opts.EmploymentTypes = uniqueStrings(query["job_type"], strings.ToLower)
+	for _, employmentType := range opts.EmploymentTypes {
+		if _, ok := employmentTypePatterns[employmentType]; !ok {
+			return fmt.Errorf("unknown job_type %q", employmentType)
+		}
+	}
+
+	opts.Companies = uniqueStrings(query["company"], nil)
+	opts.ExcludedCompanies = uniqueStrings(query["exclude_company"], nil)
+
+	for _, language := range uniqueStrings(query["required_language"], nil) {
+		code, _, ok := ResolveLanguage(language)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownLanguage, language)
+		}
+		opts.RequiredLanguages = append(opts.RequiredLanguages, code)
+	}
+
+	if value := query.Get("remote"); value != "" {
+		remote, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("remote must be true or false")
+		}
+		opts.Remote = &remote
+	}
+	return nil
+}
+
+// BuildJobFilter keeps canonical jobs with a preferred title that the seeker has not applied to,
+// saved or hidden (excludedJobIDs), narrowed by opts. Titles are matched by their JobTitleKey on the
+// indexed title_key, so any spelling of a title matches without scanning the collection.
+func BuildJobFilter(preferredTitles, excludedJobIDs []string, opts JobFilterOptions) bson.M {
+	titleKeys := []string{}
 	for _, title := range preferredTitles {
-		titleConditions = append(titleConditions, bson.M{"title": bson.M{"$regex": title, "$options": "i"}})
+		if key := JobTitleKey(title); key != "" {
+			titleKeys = append(titleKeys, key)
+		}
+	}
+
+	conditions := []bson.M{
+		{"title_key": bson.M{"$in": titleKeys}},
+		{"job_id": bson.M{"$nin": excludedJobIDs}}, // safe now
+		{"canonical_job_id": bson.M{"$exists": false}}, // one card per canonical job, duplicates are listed in its source_links
+		ActiveJobCondition(),                           // expired, filled and removed jobs are not listed
 	}
+	for _, level := range opts.LanguageLevels {
+		conditions = append(conditions, bson.M{"required_languages": bson.M{"$not": bson.M{
+			"$elemMatch": bson.M{"code": level.Code, "rank": bson.M{"$gt": level.Rank}},
+		}}})
+	}
+
+	conditions = append(conditions, JobPreferenceConditions(opts.Preferences)...)
+	conditions = append(conditions, jobQueryConditions(opts, time.Now())...)
 
 	filter := bson.M{
-		"$and": []bson.M{
-			{"$or": titleConditions},
-			{"job_id": bson.M{"$nin": appliedJobIDs}}, // safe now
-		},
+		"$and": conditions,
 	}
 	return filter
-}
\ No newline at end of file
+}
+
+// jobQueryConditions turns the query string filters into conditions. Unlike the saved preferences,
+// an explicit filter drops jobs that do not state the field, except salary which few postings state.
+func jobQueryConditions(opts JobFilterOptions, now time.Time) []bson.M {
+	var conditions []bson.M
+
+	if len(opts.Locations) > 0 {
+		var patterns bson.A
+		for _, location := range opts.Locations {
+			for _, name := range CityNamesWithin(location, opts.RadiusKm) {
+				patterns = append(patterns, primitive.Regex{Pattern: `\b` + regexp.QuoteMeta(name) + `\b`, Options: "i"})
+			}
+		}
+		conditions = append(conditions, bson.M{"location": bson.M{"$in": patterns}})
+	}
+
+	if len(opts.EmploymentTypes) > 0 {
+		var patterns bson.A
+		for _, employmentType := range opts.EmploymentTypes {
+			patterns = append(patterns, primitive.Regex{Pattern: employmentTypePatterns[employmentType], Options: "i"})
+		}
+		conditions = append(conditions, bson.M{"job_type": bson.M{"$in": patterns}})
+	}
+
+	if len(opts.Companies) > 0 {
+		conditions = append(conditions, bson.M{"company": bson.M{"$in": exactMatchPatterns(opts.Companies)}})
+	}
+	if len(opts.ExcludedCompanies) > 0 {
+		conditions = append(conditions, bson.M{"company": bson.M{"$nin": exactMatchPatterns(opts.ExcludedCompanies)}})
+	}
+
+	if opts.PostedWithinDays > 0 {
+		// posted_date is stored as YYYY-MM-DD, so the cutoff compares as a string
+		conditions = append(conditions, bson.M{"posted_date": bson.M{"$gte": now.AddDate(0, 0, -opts.PostedWithinDays).Format("2006-01-02")}})
+	}
+
+	// salary is parsed at ingest; jobs stored before that get it from the BackfillJobSalaries
+	// migration, until then they have none and pass both filters
+	if opts.SalaryMin > 0 {
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"salary.max": bson.M{"$exists": false}},
+			{"salary.max": bson.M{"$gte": opts.SalaryMin}},
+		}})
+	}
+	if opts.SalaryMax > 0 {
+		conditions = append(conditions, bson.M{"$or": []bson.M{
+			{"salary.min": bson.M{"$exists": false}},
+			{"salary.min": bson.M{"$lte": opts.SalaryMax}},
+		}})
+	}
+
+	if opts.Remote != nil {
+		remote := []bson.M{
+			{"work_mode": models.WorkModeRemote},
+			{"location": primitive.Regex{Pattern: `remote`, Options: "i"}},
+		}
+		if *opts.Remote {
+			conditions = append(conditions, bson.M{"$or": remote})
+		} else {
+			conditions = append(conditions, bson.M{"$nor": remote})
+		}
+	}
+
+	if len(opts.RequiredLanguages) > 0 {
+		conditions = append(conditions, bson.M{"required_languages.code": bson.M{"$all": opts.RequiredLanguages}})
+	}
+
+	return conditions
+}
+
+// ValidJobSort reports whether value is one of the JobSort* orders
+func ValidJobSort(value string) bool {
+	switch value {
+	case JobSortMatch, JobSortPosted, JobSortPopular:
+		return true
+	}
+	return false
+}
+
+// JobFeedSort is the keyset sort of a feed order, see Page
+func JobFeedSort(sortBy string) []SortKey {
+	var sort []SortKey
+	switch sortBy {
+	case JobSortMatch:
+		sort = []SortKey{{Field: "match_score", Desc: true}}
+	case JobSortPopular:
+		sort = []SortKey{{Field: "selected_count", Desc: true}, {Field: "view_count", Desc: true}}
+	}
+	return append(sort, SortKey{Field: "posted_date", Desc: true}, SortKey{Field: "_id"})
+}
+
+// JobFeedPipeline selects the jobs matching filter and gives every job a match_score,
+// the share of its skills found in userSkills (0-100). The page stages go after it.
+func JobFeedPipeline(filter bson.M, userSkills []string) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"match_score": matchScoreExpression(userSkills)}}},
+	}
+}
+
+// matchScoreExpression computes SkillMatchScore inside the database
+func matchScoreExpression(userSkills []string) bson.M {
+	lowered := bson.A{}
+	for _, skill := range userSkills {
+		lowered = append(lowered, strings.ToLower(strings.TrimSpace(skill)))
+	}
+	jobSkills := bson.M{"$setUnion": bson.A{
+		bson.M{"$filter": bson.M{
+			"input": bson.M{"$map": bson.M{
+				"input": bson.M{"$split": bson.A{bson.M{"$ifNull": bson.A{"$skills", ""}}, ","}},
+				"as":    "skill",
+				"in":    bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$$skill"}}},
+			}},
+			"as":   "skill",
+			"cond": bson.M{"$ne": bson.A{"$$skill", ""}},
+		}},
+		bson.A{},
+	}}
+	return bson.M{"$let": bson.M{
+		"vars": bson.M{"job": jobSkills},
+		"in": bson.M{"$cond": bson.A{
+			bson.M{"$eq": bson.A{bson.M{"$size": "$$job"}, 0}},
+			0,
+			bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{
+				bson.M{"$divide": bson.A{
+					bson.M{"$size": bson.M{"$setIntersection": bson.A{"$$job", lowered}}},
+					bson.M{"$size": "$$job"},
+				}},
+				100,
+			}}, 0}},
+		}},
+	}}
+}
+
+// SkillMatchScore is the share of the job's comma-separated skills found in userSkills, 0-100.
+// It matches the match_score of JobFeedPipeline.
+func SkillMatchScore(jobSkills string, userSkills []string) float64 {
+	have := map[string]bool{}
+	for _, skill := range userSkills {
+		have[strings.ToLower(strings.TrimSpace(skill))] = true
+	}
+	required := map[string]bool{}
+	for _, skill := range strings.Split(jobSkills, ",") {
+		if skill = strings.ToLower(strings.TrimSpace(skill)); skill != "" {
+			required[skill] = true
+		}
+	}
+	if len(required) == 0 {
+		return 0
+	}
+	matched := 0
+	for skill := range required {
+		if have[skill] {
+			matched++
+		}
+	}
+	return math.Round(float64(matched) / float64(len(required)) * 100)
+}
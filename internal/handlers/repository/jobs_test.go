@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildJobFilterMatchesTitleKeys(t *testing.T) {
+	filter := BuildJobFilter([]string{"  Backend   Developer ", "C++ Developer", ""}, []string{"job-1"}, JobFilterOptions{})
+	conditions := filter["$and"].([]bson.M)
+	want := bson.M{"title_key": bson.M{"$in": []string{"backend developer", "c++ developer"}}}
+	if !reflect.DeepEqual(conditions[0], want) {
+		t.Errorf("title condition = %v, want %v", conditions[0], want)
+	}
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Posting date facet buckets, also accepted by the posted filter
+const (
+	PostedLastDay   = "last_day"
+	PostedLastWeek  = "last_week"
+	PostedLastMonth = "last_month"
+	PostedOlder     = "older"
+)
+
+// maxFacetValues caps each value facet to the most frequent entries
+const maxFacetValues = 20
+
+// JobSearchParams is a full-text query with optional facet filters
+type JobSearchParams struct {
+	Query    string
+	Location string
+	JobType  string
+	Company  string
+	Posted   string // One of the Posted* buckets
+	Page     Page   // Built with JobSearchSort
+}
+
+// FacetCount is one value of a facet and the number of matching jobs
+type FacetCount struct {
+	Value string `json:"value" bson:"_id"`
+	Count int64  `json:"count" bson:"count"`
+}
+
+// ScoredJob is a search hit with its text relevance
+type ScoredJob struct {
+	models.Job `bson:",inline"`
+	Score      float64 `bson:"score"`
+}
+
+// JobSearchResult is one page of hits, its pagination with the total and the facet counts over all hits
+type JobSearchResult struct {
+	Jobs   []ScoredJob
+	Info   PageInfo
+	Facets map[string][]FacetCount
+}
+
+// JobSearchSort ranks hits by relevance, newer postings first among equally relevant ones.
+// The text score of a job only depends on the query, so it is a stable keyset field.
+func JobSearchSort() []SortKey {
+	return []SortKey{{Field: "score", Desc: true}, {Field: "posted_date", Desc: true}, {Field: "_id"}}
+}
+
+// ValidPostedBucket reports whether value names a posting date bucket
+func ValidPostedBucket(value string) bool {
+	switch value {
+	case PostedLastDay, PostedLastWeek, PostedLastMonth, PostedOlder:
+		return true
+	}
+	return false
+}
+
+// SearchJobs runs a text search over title, skills, company and description (the job_text index),
+// ranked by relevance and paged with keyset cursors. Facet counts are computed over every hit with the filters applied.
+// Duplicate postings are left out, their canonical job stands for them, and so are closed jobs.
+func SearchJobs(ctx context.Context, db *mongo.Database, params JobSearchParams, now time.Time) (JobSearchResult, error) {
+	match := bson.M{
+		"$text":            bson.M{"$search": params.Query},
+		"canonical_job_id": bson.M{"$exists": false},
+		"status":           ActiveJobCondition()["status"],
+	}
+	if params.Location != "" {
+		match["location"] = NormalizeJobLocation(params.Location)
+	}
+	if params.JobType != "" {
+		match["job_type"] = params.JobType
+	}
+	if params.Company != "" {
+		match["company"] = params.Company
+	}
+	if params.Posted != "" {
+		match["posted_date"] = postedDateCondition(params.Posted, now)
+	}
+
+	valueFacet := func(field string) bson.A {
+		return bson.A{
+			bson.M{"$match": bson.M{field: bson.M{"$nin": bson.A{nil, ""}}}},
+			bson.M{"$group": bson.M{"_id": "$" + field, "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": maxFacetValues},
+		}
+	}
+
+	// posted_date is stored as YYYY-MM-DD, so the buckets compare strings
+	day, week, month := postedCutoffs(now)
+	postedBucket := bson.M{"$switch": bson.M{
+		"branches": bson.A{
+			bson.M{"case": bson.M{"$gte": bson.A{"$posted_date", day}}, "then": PostedLastDay},
+			bson.M{"case": bson.M{"$gte": bson.A{"$posted_date", week}}, "then": PostedLastWeek},
+			bson.M{"case": bson.M{"$gte": bson.A{"$posted_date", month}}, "then": PostedLastMonth},
+		},
+		"default": PostedOlder,
+	}}
+
+	// The page's keyset stages run inside the facet, after the score has been added
+	jobsBranch := bson.A{}
+	for _, stage := range params.Page.Stages() {
+		jobsBranch = append(jobsBranch, stage)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+		{{Key: "$facet", Value: bson.M{
+			"jobs":     jobsBranch,
+			"total":    bson.A{bson.M{"$count": "count"}},
+			"location": valueFacet("location"),
+			"job_type": valueFacet("job_type"),
+			"company":  valueFacet("company"),
+			"posted": bson.A{
+				bson.M{"$group": bson.M{"_id": postedBucket, "count": bson.M{"$sum": 1}}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+		}}},
+	}
+
+	cursor, err := db.Collection("jobs").Aggregate(ctx, pipeline)
+	if err != nil {
+		return JobSearchResult{}, err
+	}
+	var pages []struct {
+		Jobs  []bson.Raw `bson:"jobs"`
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Location []FacetCount `bson:"location"`
+		JobType  []FacetCount `bson:"job_type"`
+		Company  []FacetCount `bson:"company"`
+		Posted   []FacetCount `bson:"posted"`
+	}
+	if err := cursor.All(ctx, &pages); err != nil {
+		return JobSearchResult{}, err
+	}
+
+	var total int64
+	result := JobSearchResult{Jobs: []ScoredJob{}, Info: PageInfo{Limit: params.Page.Limit, Total: &total}, Facets: map[string][]FacetCount{}}
+	if len(pages) == 0 {
+		return result, nil
+	}
+	page := pages[0]
+	docs, info, err := params.Page.Trim(page.Jobs)
+	if err != nil {
+		return JobSearchResult{}, err
+	}
+	for _, doc := range docs {
+		var hit ScoredJob
+		if err := bson.Unmarshal(doc, &hit); err != nil {
+			return JobSearchResult{}, err
+		}
+		result.Jobs = append(result.Jobs, hit)
+	}
+	if len(page.Total) > 0 {
+		total = page.Total[0].Count
+	}
+	info.Total = &total
+	result.Info = info
+	result.Facets["location"] = nonNilFacet(page.Location)
+	result.Facets["job_type"] = nonNilFacet(page.JobType)
+	result.Facets["company"] = nonNilFacet(page.Company)
+	result.Facets["posted"] = nonNilFacet(page.Posted)
+	return result, nil
+}
+
+// postedDateCondition matches the posted_date values of one bucket
+func postedDateCondition(bucket string, now time.Time) bson.M {
+	day, week, month := postedCutoffs(now)
+	switch bucket {
+	case PostedLastDay:
+		return bson.M{"$gte": day}
+	case PostedLastWeek:
+		return bson.M{"$gte": week, "$lt": day}
+	case PostedLastMonth:
+		return bson.M{"$gte": month, "$lt": week}
+	default:
+		return bson.M{"$lt": month}
+	}
+}
+
+func postedCutoffs(now time.Time) (string, string, string) {
+	const layout = "2006-01-02"
+	return now.AddDate(0, 0, -1).Format(layout), now.AddDate(0, 0, -7).Format(layout), now.AddDate(0, 0, -30).Format(layout)
+}
+
+func nonNilFacet(counts []FacetCount) []FacetCount {
+	if counts == nil {
+		return []FacetCount{}
+	}
+	return counts
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// jobSequence is the counter behind the numeric job ids shown on the cards
+const jobSequence = "job_id"
+
+// AssignJobSeqIDs gives the jobs without a seq_id one, in at most three round trips whatever
+// the number of jobs: reserve a range, store it, and re-read the ids another request stored first.
+// The slice is updated in place.
+func AssignJobSeqIDs(ctx context.Context, db *mongo.Database, jobs []models.Job) error {
+	var missing []int
+	for i, job := range jobs {
+		if job.SeqID == 0 {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	first, err := ReserveSequenceRange(ctx, db, jobSequence, len(missing))
+	if err != nil {
+		return err
+	}
+	writes := make([]mongo.WriteModel, 0, len(missing))
+	jobIDs := make([]string, 0, len(missing))
+	for n, i := range missing {
+		jobs[i].SeqID = first + uint(n)
+		jobIDs = append(jobIDs, jobs[i].JobID)
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"job_id": jobs[i].JobID, "seq_id": bson.M{"$exists": false}}).
+			SetUpdate(bson.M{"$set": bson.M{"seq_id": jobs[i].SeqID}}))
+	}
+	result, err := db.Collection("jobs").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return err
+	}
+	if result.ModifiedCount == int64(len(writes)) {
+		return nil
+	}
+
+	// A concurrent request numbered some of the jobs first, its ids are the stored ones
+	cursor, err := db.Collection("jobs").Find(ctx, bson.M{"job_id": bson.M{"$in": jobIDs}},
+		options.Find().SetProjection(bson.M{"job_id": 1, "seq_id": 1}))
+	if err != nil {
+		return err
+	}
+	var stored []models.Job
+	if err := cursor.All(ctx, &stored); err != nil {
+		return err
+	}
+	seqIDs := map[string]uint{}
+	for _, job := range stored {
+		seqIDs[job.JobID] = job.SeqID
+	}
+	for _, i := range missing {
+		if seqID, ok := seqIDs[jobs[i].JobID]; ok && seqID != 0 {
+			jobs[i].SeqID = seqID
+		}
+	}
+	return nil
+}
+
+// AssignIngestedJobSeqIDs numbers the stored jobs of a batch that have no seq_id yet, new ones and
+// jobs stored before seq_id existed
+func AssignIngestedJobSeqIDs(ctx context.Context, db *mongo.Database, jobIDs []string) error {
+	if len(jobIDs) == 0 {
+		return nil
+	}
+	cursor, err := db.Collection("jobs").Find(ctx,
+		bson.M{"job_id": bson.M{"$in": jobIDs}, "seq_id": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{"job_id": 1}))
+	if err != nil {
+		return err
+	}
+	var jobs []models.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return err
+	}
+	return AssignJobSeqIDs(ctx, db, jobs)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"RAAS/core/config"
+	"RAAS/internal/models"
+
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JobTitleCooldown returns how long a seeker on the given tier must wait between two title changes
+func JobTitleCooldown(tier string) time.Duration {
+	days := 30
+	if config.Cfg != nil && config.Cfg.Project != nil {
+		days = config.Cfg.Project.JobTitleCooldownFreeDays
+		if tier == "premium" {
+			days = config.Cfg.Project.JobTitleCooldownPremiumDays
+		}
+	}
+	if days < 0 {
+		days = 0
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// JobTitleKey is the lookup form of a title: lower case and single spaced. Jobs store it as the
+// indexed title_key, which NormalizeJobTitles and the feed's BuildJobFilter match instead of a regex.
+func JobTitleKey(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// NormalizeJobTitle cleans up a typed title and, when jobs with the same title exist
+// (case-insensitive), returns the spelling used most often in the jobs collection.
+func NormalizeJobTitle(ctx context.Context, db *mongo.Database, title string) string {
+	title = strings.Join(strings.Fields(title), " ")
+	if title == "" {
+		return ""
+	}
+	if spelling, ok := NormalizeJobTitles(ctx, db, []string{title})[JobTitleKey(title)]; ok {
+		return spelling
+	}
+	return title
+}
+
+// NormalizeJobTitles looks up the most used spelling of several titles in one query.
+// The result is keyed by JobTitleKey; titles no job uses yet are missing from it.
+func NormalizeJobTitles(ctx context.Context, db *mongo.Database, titles []string) map[string]string {
+	spellings := map[string]string{}
+	var keys []string
+	seen := map[string]bool{}
+	for _, title := range titles {
+		if key := JobTitleKey(title); key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return spellings
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"title_key": bson.M{"$in": keys}}}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"key": "$title_key", "title": "$title"}, "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id.title", Value: 1}}}},
+	}
+	cursor, err := db.Collection("jobs").Aggregate(ctx, pipeline)
+	if err != nil {
+		return spellings
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		ID struct {
+			Key   string `bson:"key"`
+			Title string `bson:"title"`
+		} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return spellings
+	}
+	// Sorted by use, so the first spelling of each key wins
+	for _, r := range result {
+		if _, ok := spellings[r.ID.Key]; !ok && r.ID.Title != "" {
+			spellings[r.ID.Key] = r.ID.Title
+		}
+	}
+	return spellings
+}
+
+// AssignJobTitleKeys stores title_key on the jobs written before it existed and returns how many were updated
+func AssignJobTitleKeys(ctx context.Context, db *mongo.Database) (int, error) {
+	collection := db.Collection("jobs")
+	cursor, err := collection.Find(ctx, bson.M{"title_key": bson.M{"$exists": false}}, options.Find().SetProjection(bson.M{"job_id": 1, "title": 1}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	var writes []mongo.WriteModel
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		result, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if result != nil {
+			updated += int(result.ModifiedCount)
+		}
+		writes = writes[:0]
+		return err
+	}
+	for cursor.Next(ctx) {
+		var job models.Job
+		if err := cursor.Decode(&job); err != nil {
+			continue
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"job_id": job.JobID}).
+			SetUpdate(bson.M{"$set": bson.M{"title_key": JobTitleKey(job.Title)}}))
+		if len(writes) == 500 {
+			if err := flush(); err != nil {
+				return updated, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return updated, err
+	}
+	return updated, cursor.Err()
+}
+
+// NormalizeOptionalJobTitle normalizes a secondary or tertiary title; blank titles become nil
+func NormalizeOptionalJobTitle(ctx context.Context, db *mongo.Database, title *string) *string {
+	if title == nil {
+		return nil
+	}
+	normalized := NormalizeJobTitle(ctx, db, *title)
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
+
+// SameJobTitles tells whether two title sets are equal, ignoring case
+func SameJobTitles(a, b models.JobTitleSet) bool {
+	optional := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return strings.ToLower(*s)
+	}
+	return strings.EqualFold(a.PrimaryTitle, b.PrimaryTitle) &&
+		optional(a.SecondaryTitle) == optional(b.SecondaryTitle) &&
+		optional(a.TertiaryTitle) == optional(b.TertiaryTitle)
+}
+
+// RecordJobTitleChange appends an entry to the job title history of a seeker
+func RecordJobTitleChange(ctx context.Context, db *mongo.Database, userID string, previous, current models.JobTitleSet, changedAt time.Time) error {
+	_, err := db.Collection("job_title_history").InsertOne(ctx, models.JobTitleChange{
+		AuthUserID: userID,
+		Previous:   previous,
+		Current:    current,
+		ChangedAt:  changedAt,
+	})
+	return err
+}
@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/models"
+	"RAAS/utils"
+
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const JSONResumeSchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// ImportedProfile holds the seeker sections decoded from a JSON Resume document.
+// Nil sections were absent from the document and must be left untouched.
+type ImportedProfile struct {
+	PersonalInfo        bson.M
+	ProfessionalSummary bson.M
+	WorkExperiences     []bson.M
+	Education           []bson.M
+	Certificates        []bson.M
+	Languages           []bson.M
+	JobTitles           []string
+	Email               string
+	Phone               string
+}
+
+// SeekerToJSONResume maps the seeker profile and contact details onto the JSON Resume schema
+func SeekerToJSONResume(seeker *models.Seeker, authUser *models.AuthUser) dto.JSONResume {
+	resume := dto.JSONResume{
+		Schema: JSONResumeSchemaURL,
+		Basics: dto.JSONResumeBasics{
+			Email: authUser.Email,
+			Phone: authUser.Phone,
+			Label: seeker.PrimaryTitle,
+		},
+		Work:         []dto.JSONResumeWork{},
+		Education:    []dto.JSONResumeEducation{},
+		Certificates: []dto.JSONResumeCertificate{},
+		Skills:       []dto.JSONResumeSkill{},
+		Languages:    []dto.JSONResumeLanguage{},
+	}
+
+	if personalInfo, err := GetPersonalInfo(seeker); err == nil {
+		resume.Basics.Name = strings.TrimSpace(personalInfo.FirstName + " " + dereferenceString(personalInfo.SecondName))
+		if personalInfo.Address != "" {
+			resume.Basics.Location = &dto.JSONResumeLocation{Address: personalInfo.Address}
+		}
+		if linkedIn := dereferenceString(personalInfo.LinkedInProfile); linkedIn != "" {
+			resume.Basics.Profiles = append(resume.Basics.Profiles, dto.JSONResumeProfile{Network: "LinkedIn", URL: linkedIn})
+		}
+	}
+
+	if IsFieldFilled(seeker.ProfessionalSummary) {
+		if summary, err := GetProfessionalSummary(seeker); err == nil {
+			resume.Basics.Summary = summary.About
+			for _, skill := range summary.Skills {
+				resume.Skills = append(resume.Skills, dto.JSONResumeSkill{Name: skill})
+			}
+		}
+	}
+
+	for _, exp := range seeker.WorkExperiences {
+		resume.Work = append(resume.Work, dto.JSONResumeWork{
+			Name:      stringField(exp, "company_name"),
+			Position:  stringField(exp, "job_title"),
+			StartDate: FormatStoredDate(exp["start_date"]),
+			EndDate:   FormatStoredDate(exp["end_date"]),
+			Summary:   stringField(exp, "key_responsibilities"),
+		})
+	}
+
+	for _, edu := range seeker.Education {
+		entry := dto.JSONResumeEducation{
+			Institution: stringField(edu, "institution"),
+			Area:        stringField(edu, "field_of_study"),
+			StudyType:   stringField(edu, "degree"),
+			StartDate:   FormatStoredDate(edu["start_date"]),
+			EndDate:     FormatStoredDate(edu["end_date"]),
+		}
+		if achievements := stringField(edu, "achievements"); achievements != "" {
+			entry.Courses = strings.Split(achievements, "\n")
+		}
+		resume.Education = append(resume.Education, entry)
+	}
+
+	for _, cert := range seeker.Certificates {
+		resume.Certificates = append(resume.Certificates, dto.JSONResumeCertificate{
+			Name:   stringField(cert, "certificate_name"),
+			Date:   FormatStoredDate(cert["issue_date"]),
+			Issuer: stringField(cert, "issuer"),
+			URL:    stringField(cert, "certificate_file"),
+		})
+	}
+
+	for _, lang := range seeker.Languages {
+		resume.Languages = append(resume.Languages, dto.JSONResumeLanguage{
+			Language: stringField(lang, "language"),
+			Fluency:  stringField(lang, "proficiency"),
+		})
+	}
+
+	if titles := CollectPreferredTitles(*seeker); len(titles) > 0 {
+		resume.Meta = &dto.JSONResumeMeta{JobTitles: titles}
+	}
+
+	return resume
+}
+
+// JSONResumeToSeeker validates a JSON Resume document and converts it into seeker sections.
+// The existing seeker is used for fields the schema does not carry (date of birth, annual income).
+// All validation errors are collected and returned together, keyed by their JSON path.
+func JSONResumeToSeeker(resume dto.JSONResume, existing *models.Seeker) (*ImportedProfile, []dto.FieldError) {
+	var errs []dto.FieldError
+	addErr := func(field, message string) {
+		errs = append(errs, dto.FieldError{Field: field, Message: message})
+	}
+
+	imported := &ImportedProfile{}
+
+	// Basics
+	name := strings.TrimSpace(resume.Basics.Name)
+	if name == "" {
+		addErr("basics.name", "is required")
+	}
+	if resume.Basics.Email != "" {
+		if _, err := mail.ParseAddress(resume.Basics.Email); err != nil {
+			addErr("basics.email", "is not a valid email address")
+		} else {
+			imported.Email = resume.Basics.Email
+		}
+	}
+	if phone := strings.TrimSpace(resume.Basics.Phone); phone != "" {
+		if len(phone) < 10 || len(phone) > 15 {
+			addErr("basics.phone", "must be between 10 and 15 characters")
+		} else {
+			imported.Phone = phone
+		}
+	}
+
+	if name != "" {
+		personalInfo := dto.PersonalInfoRequest{}
+		if current, err := GetPersonalInfo(existing); err == nil {
+			personalInfo = *current
+		}
+		parts := strings.Fields(name)
+		personalInfo.FirstName = parts[0]
+		personalInfo.SecondName = nil
+		if len(parts) > 1 {
+			secondName := strings.Join(parts[1:], " ")
+			personalInfo.SecondName = &secondName
+		}
+		if address := jsonResumeAddress(resume.Basics.Location); address != "" {
+			personalInfo.Address = address
+		}
+		for _, profile := range resume.Basics.Profiles {
+			if strings.EqualFold(profile.Network, "linkedin") && profile.URL != "" {
+				linkedIn := profile.URL
+				personalInfo.LinkedInProfile = &linkedIn
+			}
+		}
+		if bsonData, err := MarshalStructToBson(&personalInfo); err == nil {
+			imported.PersonalInfo = bsonData
+		}
+	}
+
+	// Summary and skills
+	if resume.Basics.Summary != "" || resume.Skills != nil {
+		summary := dto.ProfessionalSummaryRequest{Skills: []string{}}
+		if IsFieldFilled(existing.ProfessionalSummary) {
+			if current, err := GetProfessionalSummary(existing); err == nil {
+				summary = *current
+			}
+		}
+		if resume.Basics.Summary != "" {
+			summary.About = resume.Basics.Summary
+		}
+		if resume.Skills != nil {
+			summary.Skills = []string{}
+			seen := map[string]bool{}
+			for i, skill := range resume.Skills {
+				if strings.TrimSpace(skill.Name) == "" {
+					addErr(fmt.Sprintf("skills[%d].name", i), "is required")
+					continue
+				}
+				for _, s := range append([]string{skill.Name}, skill.Keywords...) {
+					s = strings.TrimSpace(s)
+					if s != "" && !seen[strings.ToLower(s)] {
+						seen[strings.ToLower(s)] = true
+						summary.Skills = append(summary.Skills, s)
+					}
+				}
+			}
+		}
+		if bsonData, err := MarshalStructToBson(&summary); err == nil {
+			imported.ProfessionalSummary = bsonData
+		}
+	}
+
+	// Work
+	if resume.Work != nil {
+		holder := &models.Seeker{WorkExperiences: []bson.M{}}
+		for i, work := range resume.Work {
+			path := fmt.Sprintf("work[%d]", i)
+			if strings.TrimSpace(work.Name) == "" {
+				addErr(path+".name", "is required")
+			}
+			if strings.TrimSpace(work.Position) == "" {
+				addErr(path+".position", "is required")
+			}
+			start, end, ok := parseJSONResumeRange(work.StartDate, work.EndDate, path, addErr)
+			if !ok {
+				continue
+			}
+			if dateErrs := ValidateDateRange(WorkDateRange, start, end, time.Now()); len(dateErrs) > 0 {
+				for _, dateErr := range dateErrs {
+					addErr(path+"."+jsonResumeDateFields[dateErr.Field], dateErr.Message)
+				}
+				continue
+			}
+			responsibilities := work.Summary
+			if len(work.Highlights) > 0 {
+				responsibilities = strings.TrimSpace(responsibilities + "\n" + strings.Join(work.Highlights, "\n"))
+			}
+			AppendToWorkExperience(holder, dto.WorkExperienceRequest{
+				JobTitle:            work.Position,
+				CompanyName:         work.Name,
+				EmploymentType:      "Unspecified",
+				StartDate:           start,
+				EndDate:             end,
+				KeyResponsibilities: responsibilities,
+			})
+		}
+		imported.WorkExperiences = holder.WorkExperiences
+	}
+
+	// Education
+	if resume.Education != nil {
+		holder := &models.Seeker{Education: []bson.M{}}
+		for i, edu := range resume.Education {
+			path := fmt.Sprintf("education[%d]", i)
+			if strings.TrimSpace(edu.Institution) == "" {
+				addErr(path+".institution", "is required")
+			}
+			if strings.TrimSpace(edu.StudyType) == "" {
+				addErr(path+".studyType", "is required")
+			}
+			if strings.TrimSpace(edu.Area) == "" {
+				addErr(path+".area", "is required")
+			}
+			start, end, ok := parseJSONResumeRange(edu.StartDate, edu.EndDate, path, addErr)
+			if !ok {
+				continue
+			}
+			if dateErrs := ValidateDateRange(EducationDateRange, start, end, time.Now()); len(dateErrs) > 0 {
+				for _, dateErr := range dateErrs {
+					addErr(path+"."+jsonResumeDateFields[dateErr.Field], dateErr.Message)
+				}
+				continue
+			}
+			AppendToEducation(holder, dto.EducationRequest{
+				Degree:       edu.StudyType,
+				Institution:  edu.Institution,
+				FieldOfStudy: edu.Area,
+				StartDate:    start,
+				EndDate:      end,
+				Achievements: strings.Join(edu.Courses, "\n"),
+			})
+		}
+		imported.Education = holder.Education
+	}
+
+	// Certificates
+	if resume.Certificates != nil {
+		holder := &models.Seeker{Certificates: []bson.M{}}
+		for i, cert := range resume.Certificates {
+			if strings.TrimSpace(cert.Name) == "" {
+				addErr(fmt.Sprintf("certificates[%d].name", i), "is required")
+				continue
+			}
+			request := dto.CertificateRequest{CertificateName: cert.Name, Issuer: cert.Issuer}
+			if cert.Date != "" {
+				issued, err := parseJSONResumeDate(cert.Date)
+				if err != nil {
+					addErr(fmt.Sprintf("certificates[%d].date", i), "must be YYYY-MM-DD, YYYY-MM or YYYY")
+					continue
+				}
+				request.IssueDate = issued.Format("2006-01-02")
+			}
+			if err := AppendToCertificates(holder, request, cert.URL); err != nil {
+				addErr(fmt.Sprintf("certificates[%d]", i), err.Error())
+			}
+		}
+		imported.Certificates = holder.Certificates
+	}
+
+	// Languages
+	if resume.Languages != nil {
+		holder := &models.Seeker{Languages: []bson.M{}}
+		for i, lang := range resume.Languages {
+			path := fmt.Sprintf("languages[%d]", i)
+			if strings.TrimSpace(lang.Language) == "" {
+				addErr(path+".language", "is required")
+			}
+			if strings.TrimSpace(lang.Fluency) == "" {
+				addErr(path+".fluency", "is required")
+			}
+			if err := AppendToLanguages(holder, dto.LanguageRequest{LanguageName: lang.Language, ProficiencyLevel: lang.Fluency}, ""); err != nil && strings.TrimSpace(lang.Language) != "" && strings.TrimSpace(lang.Fluency) != "" {
+				addErr(path, err.Error())
+			}
+		}
+		imported.Languages = holder.Languages
+	}
+
+	// Job titles
+	if resume.Meta != nil && len(resume.Meta.JobTitles) > 0 {
+		if len(resume.Meta.JobTitles) > 3 {
+			addErr("meta.jobTitles", "accepts at most 3 titles")
+		}
+		for _, title := range resume.Meta.JobTitles {
+			if title = strings.TrimSpace(title); title != "" && len(imported.JobTitles) < 3 {
+				imported.JobTitles = append(imported.JobTitles, title)
+			}
+		}
+	} else if label := strings.TrimSpace(resume.Basics.Label); label != "" {
+		imported.JobTitles = []string{label}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return imported, nil
+}
+
+// parseJSONResumeDate accepts the ISO 8601 forms allowed by the schema: YYYY-MM-DD, YYYY-MM and YYYY
+func parseJSONResumeDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// jsonResumeDateFields maps the date fields reported by ValidateDateRange to their JSON Resume names
+var jsonResumeDateFields = map[string]string{"start_date": "startDate", "end_date": "endDate"}
+
+func parseJSONResumeRange(startValue, endValue, path string, addErr func(field, message string)) (utils.DateOnly, *utils.DateOnly, bool) {
+	ok := true
+	var start utils.DateOnly
+	var end *utils.DateOnly
+
+	if startValue == "" {
+		addErr(path+".startDate", "is required")
+		ok = false
+	} else if t, err := parseJSONResumeDate(startValue); err != nil {
+		addErr(path+".startDate", "must be YYYY-MM-DD, YYYY-MM or YYYY")
+		ok = false
+	} else {
+		start = utils.DateOnly{Time: t}
+	}
+
+	if endValue != "" {
+		if t, err := parseJSONResumeDate(endValue); err != nil {
+			addErr(path+".endDate", "must be YYYY-MM-DD, YYYY-MM or YYYY")
+			ok = false
+		} else {
+			end = &utils.DateOnly{Time: t}
+		}
+	}
+
+	if ok && end != nil && end.Time.Before(start.Time) {
+		addErr(path+".endDate", "must not be before startDate")
+		ok = false
+	}
+	return start, end, ok
+}
+
+func jsonResumeAddress(location *dto.JSONResumeLocation) string {
+	if location == nil {
+		return ""
+	}
+	if location.Address != "" {
+		return location.Address
+	}
+	var parts []string
+	for _, part := range []string{location.PostalCode, location.City, location.Region, location.CountryCode} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// stringField reads a string value from a profile entry, returning "" when missing
+func stringField(entry bson.M, field string) string {
+	if val, ok := entry[field].(string); ok {
+		return val
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownLanguage     = errors.New("unknown language, expected an ISO 639-1 code or English name")
+	ErrInvalidProficiency  = errors.New("invalid proficiency, expected a CEFR level (A1-C2) or native")
+	ErrDuplicateLanguage   = errors.New("language already added")
+	ErrCertificateNotFound = errors.New("certificate not found")
+)
+
+// CEFRNative is the level stored for native speakers, ranked above C2
+const CEFRNative = "native"
+
+var cefrRanks = map[string]int{"A1": 1, "A2": 2, "B1": 3, "B2": 4, "C1": 5, "C2": 6, CEFRNative: 7}
+
+// cefrAliases maps the fluency wording found in imported resumes onto CEFR levels
+var cefrAliases = map[string]string{
+	"native speaker":                   CEFRNative,
+	"mother tongue":                    CEFRNative,
+	"bilingual":                        CEFRNative,
+	"native or bilingual proficiency":  CEFRNative,
+	"full professional proficiency":    "C1",
+	"fluent":                           "C1",
+	"advanced":                         "C1",
+	"professional working proficiency": "B2",
+	"upper intermediate":               "B2",
+	"intermediate":                     "B1",
+	"limited working proficiency":      "B1",
+	"elementary":                       "A2",
+	"elementary proficiency":           "A2",
+	"beginner":                         "A1",
+}
+
+var languageNameIndex = func() map[string]string {
+	index := map[string]string{}
+	for code, name := range iso6391Languages {
+		index[strings.ToLower(name)] = code
+	}
+	return index
+}()
+
+// NormalizeCEFRLevel validates a proficiency and returns its canonical form ("b2" -> "B2", "Native" -> "native")
+func NormalizeCEFRLevel(level string) (string, bool) {
+	level = strings.TrimSpace(level)
+	if _, ok := cefrRanks[strings.ToUpper(level)]; ok {
+		return strings.ToUpper(level), true
+	}
+	key := strings.ToLower(strings.Join(strings.Fields(level), " "))
+	if key == CEFRNative {
+		return CEFRNative, true
+	}
+	if alias, ok := cefrAliases[key]; ok {
+		return alias, true
+	}
+	return "", false
+}
+
+// CEFRRank orders levels: A1 = 1 ... C2 = 6, native = 7. Unknown levels rank 0.
+func CEFRRank(level string) int {
+	if normalized, ok := NormalizeCEFRLevel(level); ok {
+		return cefrRanks[normalized]
+	}
+	return 0
+}
+
+// ResolveLanguage accepts an ISO 639-1 code ("de") or an English name ("German")
+// and returns the code and display name
+func ResolveLanguage(input string) (string, string, bool) {
+	input = strings.TrimSpace(input)
+	if name, ok := iso6391Languages[strings.ToLower(input)]; ok {
+		return strings.ToLower(input), name, true
+	}
+	if code, ok := languageNameIndex[strings.ToLower(input)]; ok {
+		return code, iso6391Languages[code], true
+	}
+	return "", "", false
+}
+
+// LanguageCodeOf returns the ISO code of a stored language entry; older entries only carry the name
+func LanguageCodeOf(entry map[string]interface{}) string {
+	if code, _ := entry["language_code"].(string); code != "" {
+		return code
+	}
+	name, _ := entry["language"].(string)
+	code, _, _ := ResolveLanguage(name)
+	return code
+}
+
+// ParseLanguageRequirement parses "German B2" or "de:B2" into a requirement
+func ParseLanguageRequirement(value string) (models.LanguageRequirement, error) {
+	value = strings.TrimSpace(strings.Replace(value, ":", " ", 1))
+	i := strings.LastIndex(value, " ")
+	if i < 0 {
+		return models.LanguageRequirement{}, fmt.Errorf("%q must be a language followed by a level, e.g. \"German B2\"", value)
+	}
+	code, _, ok := ResolveLanguage(value[:i])
+	if !ok {
+		return models.LanguageRequirement{}, fmt.Errorf("unknown language %q", value[:i])
+	}
+	level, ok := NormalizeCEFRLevel(value[i+1:])
+	if !ok {
+		return models.LanguageRequirement{}, fmt.Errorf("unknown level %q, expected A1-C2 or native", value[i+1:])
+	}
+	return models.LanguageRequirement{Code: code, Level: level, Rank: cefrRanks[level]}, nil
+}
@@ -3,10 +3,14 @@ package repository
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"RAAS/internal/dto"
 	"RAAS/internal/models"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // --- General marshal/unmarshal helpers ---
@@ -103,7 +107,13 @@ func AppendToWorkExperience(seeker *models.Seeker, newWorkExperience dto.WorkExp
     }
 
     // Append the new work experience as a bson.M document
+    // Every entry carries a stable id, personas reference work experiences by it
+    id := newWorkExperience.ID
+    if id == "" {
+        id = primitive.NewObjectID().Hex()
+    }
     workExperienceBson := bson.M{
+        "id":                  id,
         "job_title":           newWorkExperience.JobTitle,
         "company_name":        newWorkExperience.CompanyName,
         "employment_type":     newWorkExperience.EmploymentType,
@@ -177,11 +187,20 @@ func AppendToCertificates(seeker *models.Seeker, newCertificate dto.CertificateR
 		seeker.Certificates = []bson.M{}
 	}
 
+	// Issuer, dates, credential ID and verification URL are optional
+	details, err := certificateDetails(newCertificate, time.Now())
+	if err != nil {
+		return err
+	}
+
 	// Create a new certificate entry as a bson.M document
 	certificateBson := bson.M{
 		"certificate_name":   newCertificate.CertificateName,
 		"certificate_file":   certificateFile,
 	}
+	for key, value := range details {
+		certificateBson[key] = value
+	}
 
 	// Only add certificate_number if it's not nil
 	if newCertificate.CertificateNumber != nil {
@@ -207,22 +226,55 @@ func SetLanguages(seeker *models.Seeker, languages []bson.M) error {
     seeker.Languages = languages
     return nil
 }
-// AppendToLanguages adds a new language entry to the Seeker's languages list
+// AppendToLanguages validates a language entry and adds it to the Seeker's languages list.
+// The language is stored by ISO 639-1 code with its display name and a CEFR proficiency.
 func AppendToLanguages(seeker *models.Seeker, newLanguage dto.LanguageRequest, languageFile string) error {
     // Check if the Languages array is nil or empty, if so, initialize it
     if seeker.Languages == nil {
         seeker.Languages = []bson.M{}
     }
 
+    code, name, ok := ResolveLanguage(newLanguage.LanguageName)
+    if !ok {
+        return fmt.Errorf("%w: %q", ErrUnknownLanguage, newLanguage.LanguageName)
+    }
+    level, ok := NormalizeCEFRLevel(newLanguage.ProficiencyLevel)
+    if !ok {
+        return fmt.Errorf("%w: %q", ErrInvalidProficiency, newLanguage.ProficiencyLevel)
+    }
+    for _, existing := range seeker.Languages {
+        if LanguageCodeOf(existing) == code {
+            return fmt.Errorf("%w: %s", ErrDuplicateLanguage, name)
+        }
+    }
+
     // Create a new language entry as a bson.M document
     languageBson := bson.M{
-        "language":         newLanguage.LanguageName,
-        "proficiency":      newLanguage.ProficiencyLevel,
+        "language":         name,
+        "language_code":    code,
+        "proficiency":      level,
         "certificate_file": languageFile,
     }
 
+    // Proficiency can be backed by a certificate the seeker already uploaded
+    if certificateName := strings.TrimSpace(newLanguage.CertificateName); certificateName != "" {
+        if !hasCertificate(seeker, certificateName) {
+            return fmt.Errorf("%w: %q", ErrCertificateNotFound, certificateName)
+        }
+        languageBson["certificate_name"] = certificateName
+    }
+
     // Append the new language entry to the Languages array
     seeker.Languages = append(seeker.Languages, languageBson)
 
     return nil
 }
+
+func hasCertificate(seeker *models.Seeker, certificateName string) bool {
+    for _, cert := range seeker.Certificates {
+        if name, _ := cert["certificate_name"].(string); strings.EqualFold(strings.TrimSpace(name), certificateName) {
+            return true
+        }
+    }
+    return false
+}
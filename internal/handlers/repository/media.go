@@ -7,12 +7,14 @@ import (
 
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"bytes"
 	"path/filepath"
+	"time"
 
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/gin-gonic/gin"
@@ -102,6 +104,95 @@ func (h *MediaUploadHandler) UploadGeneratedFile(c *gin.Context, containerName s
     return fileURL, nil
 }
 
+// UploadBlob stores data in a private container under ctx, creating the container when needed.
+// Unlike UploadGeneratedFile it does not need a request, so background workers can use it.
+func (h *MediaUploadHandler) UploadBlob(ctx context.Context, containerName, blobName string, data []byte) (string, error) {
+	containerURL := h.blobServiceClient.NewContainerURL(containerName)
+	if _, err := containerURL.Create(ctx, azblob.Metadata{}, azblob.PublicAccessNone); err != nil {
+		if stgErr, ok := err.(azblob.StorageError); !ok || stgErr.ServiceCode() != azblob.ServiceCodeContainerAlreadyExists {
+			return "", err
+		}
+	}
+
+	blobURL := containerURL.NewBlockBlobURL(blobName)
+	if _, err := azblob.UploadBufferToBlockBlob(ctx, data, blobURL, azblob.UploadToBlockBlobOptions{}); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s", config.Cfg.Cloud.AzureStorageAccount, containerName, blobName), nil
+}
+
+// DownloadBlob reads a file previously stored by one of the upload helpers, given its URL
+func (h *MediaUploadHandler) DownloadBlob(ctx context.Context, fileURL string) ([]byte, error) {
+	containerName, blobName, err := SplitBlobURL(fileURL)
+	if err != nil {
+		return nil, err
+	}
+	blobURL := h.blobServiceClient.NewContainerURL(containerName).NewBlobURL(blobName)
+	response, err := blobURL.Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		return nil, err
+	}
+	body := response.Body(azblob.RetryReaderOptions{MaxRetryRequests: 3})
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// DeleteBlob removes a stored file given its URL. A missing blob is not an error.
+func (h *MediaUploadHandler) DeleteBlob(ctx context.Context, fileURL string) error {
+	containerName, blobName, err := SplitBlobURL(fileURL)
+	if err != nil {
+		return err
+	}
+	blobURL := h.blobServiceClient.NewContainerURL(containerName).NewBlobURL(blobName)
+	if _, err := blobURL.Delete(ctx, azblob.DeleteSnapshotsOptionInclude, azblob.BlobAccessConditions{}); err != nil {
+		if stgErr, ok := err.(azblob.StorageError); ok && stgErr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// SplitBlobURL returns the container and blob name of a blob URL
+func SplitBlobURL(fileURL string) (string, string, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return "", "", err
+	}
+	parts := azblob.NewBlobURLParts(*u)
+	if parts.ContainerName == "" || parts.BlobName == "" {
+		return "", "", fmt.Errorf("not a blob URL: %s", fileURL)
+	}
+	return parts.ContainerName, parts.BlobName, nil
+}
+
+// SignedBlobURL returns a read-only link to a private blob that stops working at expiresAt
+func SignedBlobURL(containerName, blobName string, expiresAt time.Time) (string, error) {
+	credential, err := azblob.NewSharedKeyCredential(config.Cfg.Cloud.AzureStorageAccount, config.Cfg.Cloud.AzureStorageKey)
+	if err != nil {
+		return "", err
+	}
+	sas, err := azblob.BlobSASSignatureValues{
+		Protocol:      azblob.SASProtocolHTTPS,
+		ExpiryTime:    expiresAt.UTC(),
+		ContainerName: containerName,
+		BlobName:      blobName,
+		Permissions:   azblob.BlobSASPermissions{Read: true}.String(),
+	}.NewSASQueryParameters(credential)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s", config.Cfg.Cloud.AzureStorageAccount, containerName, blobName))
+	if err != nil {
+		return "", err
+	}
+	parts := azblob.NewBlobURLParts(*u)
+	parts.SAS = sas
+	signed := parts.URL()
+	return signed.String(), nil
+}
+
 // ValidateFileType checks if the uploaded file has a valid extension
 func (h *MediaUploadHandler) ValidateFileType(fileHeader *multipart.FileHeader) bool {
 	allowedExtensions := []string{".jpg", ".jpeg", ".png", ".docx", ".pdf"}
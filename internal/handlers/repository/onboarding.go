@@ -0,0 +1,381 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LoadOnboardingSteps returns the onboarding steps ordered by Order.
+// The built-in defaults are used when no rules are stored.
+func LoadOnboardingSteps(ctx context.Context, db *mongo.Database) ([]models.OnboardingStep, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "key", Value: 1}})
+	cursor, err := db.Collection("onboarding_steps").Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var steps []models.OnboardingStep
+	if err := cursor.All(ctx, &steps); err != nil {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		steps = models.DefaultOnboardingSteps()
+	}
+	return steps, nil
+}
+
+// ValidateOnboardingSteps checks a rule set before it replaces the stored one
+func ValidateOnboardingSteps(steps []models.OnboardingStep) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("at least one step is required")
+	}
+	seen := map[string]bool{}
+	for i, step := range steps {
+		key := strings.TrimSpace(step.Key)
+		if key == "" {
+			return fmt.Errorf("steps[%d].key is required", i)
+		}
+		if strings.ContainsAny(key, ". $") {
+			return fmt.Errorf("steps[%d].key must not contain spaces, dots or '$'", i)
+		}
+		if seen[key] {
+			return fmt.Errorf("steps[%d].key %q is duplicated", i, key)
+		}
+		seen[key] = true
+
+		for j, predicate := range step.Predicates {
+			if strings.TrimSpace(predicate.Field) == "" {
+				return fmt.Errorf("steps[%d].predicates[%d].field is required", i, j)
+			}
+			switch predicate.Op {
+			case models.PredicateExists, models.PredicateNotEmpty:
+			case models.PredicateMinItems:
+				if _, ok := toInt(predicate.Value); !ok {
+					return fmt.Errorf("steps[%d].predicates[%d].value must be a number for min_items", i, j)
+				}
+			case models.PredicateEquals:
+				if predicate.Value == nil {
+					return fmt.Errorf("steps[%d].predicates[%d].value is required for equals", i, j)
+				}
+			default:
+				return fmt.Errorf("steps[%d].predicates[%d].op %q is not supported", i, j, predicate.Op)
+			}
+		}
+	}
+	return nil
+}
+
+// IsStepRequired tells whether the step is required for a seeker on the given subscription tier
+func IsStepRequired(step models.OnboardingStep, tier string) bool {
+	if step.Required {
+		return true
+	}
+	for _, t := range step.RequiredTiers {
+		if strings.EqualFold(t, tier) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStepCompleted evaluates the step predicates against the seeker document.
+// Steps without predicates fall back to the "<key>_completed" flag of the timeline.
+func IsStepCompleted(step models.OnboardingStep, seekerDoc bson.M, timeline bson.M) bool {
+	if len(step.Predicates) == 0 {
+		completed, _ := timeline[step.Key+"_completed"].(bool)
+		return completed
+	}
+	for _, predicate := range step.Predicates {
+		if !EvaluatePredicate(seekerDoc, predicate) {
+			return false
+		}
+	}
+	return true
+}
+
+// EvaluatePredicate checks a single predicate against a document
+func EvaluatePredicate(doc bson.M, predicate models.OnboardingPredicate) bool {
+	value, found := lookupPath(doc, predicate.Field)
+
+	switch predicate.Op {
+	case models.PredicateExists:
+		return found && value != nil
+	case models.PredicateNotEmpty:
+		return found && !isEmptyValue(value)
+	case models.PredicateMinItems:
+		min, _ := toInt(predicate.Value)
+		return found && itemCount(value) >= min
+	case models.PredicateEquals:
+		if !found {
+			return false
+		}
+		if a, ok := toFloat(value); ok {
+			b, ok := toFloat(predicate.Value)
+			return ok && a == b
+		}
+		return reflect.DeepEqual(value, predicate.Value)
+	}
+	return false
+}
+
+// TimelineRequiredFlags builds the "<key>_required" flags of a timeline for a subscription tier
+func TimelineRequiredFlags(steps []models.OnboardingStep, tier string) bson.M {
+	flags := bson.M{}
+	for _, step := range steps {
+		flags[step.Key+"_required"] = IsStepRequired(step, tier)
+	}
+	return flags
+}
+
+// ReplaceOnboardingSteps stores a new rule set, dropping the steps that are no longer listed
+func ReplaceOnboardingSteps(ctx context.Context, db *mongo.Database, steps []models.OnboardingStep) error {
+	collection := db.Collection("onboarding_steps")
+
+	var keys []string
+	var writes []mongo.WriteModel
+	for _, step := range steps {
+		step.Key = strings.TrimSpace(step.Key)
+		step.UpdatedAt = time.Now()
+		keys = append(keys, step.Key)
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"key": step.Key}).
+			SetReplacement(step).
+			SetUpsert(true))
+	}
+
+	if _, err := collection.BulkWrite(ctx, writes); err != nil {
+		return err
+	}
+	_, err := collection.DeleteMany(ctx, bson.M{"key": bson.M{"$nin": keys}})
+	return err
+}
+
+// BackfillTimelines re-applies the rules to every existing timeline: the required flags are
+// rewritten per subscription tier and "completed" is re-evaluated against the seeker documents.
+// It returns the number of timelines whose overall completion changed.
+func BackfillTimelines(ctx context.Context, db *mongo.Database, steps []models.OnboardingStep) (int, error) {
+	seekersCollection := db.Collection("seekers")
+	timelinesCollection := db.Collection("user_entry_timelines")
+
+	cursor, err := timelinesCollection.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	changed := 0
+	for cursor.Next(ctx) {
+		var timeline bson.M
+		if err := cursor.Decode(&timeline); err != nil {
+			continue
+		}
+		userID, _ := timeline["auth_user_id"].(string)
+
+		var seekerDoc bson.M
+		if err := seekersCollection.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seekerDoc); err != nil {
+			continue
+		}
+
+		update := evaluateTimeline(steps, seekerDoc, timeline)
+		if previous, _ := timeline["completed"].(bool); previous != update["completed"] {
+			changed++
+		}
+		if _, err := timelinesCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": update}); err != nil {
+			return changed, err
+		}
+	}
+	return changed, cursor.Err()
+}
+
+// RefreshTimeline sets the given flags on the user's timeline and re-evaluates every step and the
+// overall "completed" flag against the stored seeker, after a write that touched several sections.
+func RefreshTimeline(ctx context.Context, db *mongo.Database, userID string, flags bson.M) error {
+	steps, err := LoadOnboardingSteps(ctx, db)
+	if err != nil {
+		return err
+	}
+	var seekerDoc bson.M
+	if err := db.Collection("seekers").FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&seekerDoc); err != nil {
+		return err
+	}
+	timelinesCollection := db.Collection("user_entry_timelines")
+	var timeline bson.M
+	if err := timelinesCollection.FindOne(ctx, bson.M{"auth_user_id": userID}).Decode(&timeline); err != nil {
+		return err
+	}
+	for key, value := range flags {
+		timeline[key] = value
+	}
+
+	update := evaluateTimeline(steps, seekerDoc, timeline)
+	for key, value := range flags {
+		if _, set := update[key]; !set {
+			update[key] = value
+		}
+	}
+	_, err = timelinesCollection.UpdateOne(ctx, bson.M{"auth_user_id": userID}, bson.M{"$set": update})
+	return err
+}
+
+// evaluateTimeline computes the required and completed flags of every step and the overall "completed" flag
+func evaluateTimeline(steps []models.OnboardingStep, seekerDoc bson.M, timeline bson.M) bson.M {
+	tier, _ := seekerDoc["subscription_tier"].(string)
+
+	update := TimelineRequiredFlags(steps, tier)
+	completed := true
+	for _, step := range steps {
+		stepCompleted := IsStepCompleted(step, seekerDoc, timeline)
+		update[step.Key+"_completed"] = stepCompleted
+		if IsStepRequired(step, tier) && !stepCompleted {
+			completed = false
+		}
+	}
+	update["completed"] = completed
+	update["updated_at"] = time.Now()
+	return update
+}
+
+// AddDefaultOnboardingSteps stores the default steps that were introduced after the deployment
+// was seeded, then backfills the timelines. Each default key is offered once and recorded in the
+// "migrations" collection, so a step an admin removed is not brought back. It returns the number of steps added.
+func AddDefaultOnboardingSteps(ctx context.Context, db *mongo.Database) (int, error) {
+	stepsCollection := db.Collection("onboarding_steps")
+	migrationsCollection := db.Collection("migrations")
+
+	added := 0
+	for _, step := range models.DefaultOnboardingSteps() {
+		migrationID := "onboarding_step:" + step.Key
+		count, err := migrationsCollection.CountDocuments(ctx, bson.M{"_id": migrationID}, options.Count().SetLimit(1))
+		if err != nil {
+			return added, err
+		}
+		if count > 0 {
+			continue
+		}
+
+		step.UpdatedAt = time.Now()
+		result, err := stepsCollection.UpdateOne(ctx, bson.M{"key": step.Key}, bson.M{"$setOnInsert": step}, options.Update().SetUpsert(true))
+		if err != nil {
+			return added, err
+		}
+		added += int(result.UpsertedCount)
+		if _, err := migrationsCollection.UpdateOne(ctx,
+			bson.M{"_id": migrationID},
+			bson.M{"$setOnInsert": bson.M{"applied_at": time.Now()}},
+			options.Update().SetUpsert(true),
+		); err != nil {
+			return added, err
+		}
+	}
+	if added == 0 {
+		return 0, nil
+	}
+
+	steps, err := LoadOnboardingSteps(ctx, db)
+	if err != nil {
+		return added, err
+	}
+	if _, err := BackfillTimelines(ctx, db, steps); err != nil {
+		return added, err
+	}
+	return added, nil
+}
+
+// lookupPath resolves a dotted path inside a document, e.g. "personal_info.first_name"
+func lookupPath(doc bson.M, path string) (interface{}, bool) {
+	var current interface{} = doc
+	for _, part := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case bson.M:
+			value, ok := v[part]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case map[string]interface{}:
+			value, ok := v[part]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case bson.D:
+			found := false
+			for _, e := range v {
+				if e.Key == part {
+					current, found = e.Value, true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case bson.M:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case bson.D:
+		return len(v) == 0
+	case bson.A, []interface{}, []bson.M, []string:
+		return itemCount(v) == 0
+	case primitive.DateTime:
+		return v == 0
+	}
+	return false
+}
+
+func itemCount(value interface{}) int {
+	switch v := value.(type) {
+	case bson.A:
+		return len(v)
+	case []interface{}:
+		return len(v)
+	case []bson.M:
+		return len(v)
+	case []string:
+		return len(v)
+	}
+	return 0
+}
+
+func toInt(value interface{}) (int, bool) {
+	f, ok := toFloat(value)
+	return int(f), ok
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
@@ -33,19 +33,6 @@ func IsFieldFilled(personalInfo bson.M) bool {
 	return len(personalInfo) > 0
 }
 
-func randomSalary() (int, int) {
-	// Example random salary range logic, adjust as needed
-	minSalary := 25000 // Example minimum salary
-	maxSalary := 35000 // Example maximum salary
-	return minSalary, maxSalary
-}
-
-// Generate expected salary range
-func GenerateSalaryRange() models.SalaryRange {
-	min, max := randomSalary() // Use your existing function
-	return models.SalaryRange{Min: min, Max: max}
-}
-
 func dereferenceString(str *string) string {
 	if str != nil {
 		return *str
@@ -78,4 +65,23 @@ func GetNextSequence(db *mongo.Database, name string) (uint, error) {
 	return result.SequenceValue, err
 }
 
+// ReserveSequenceRange takes n consecutive values of a counter in one round trip and returns the first
+func ReserveSequenceRange(ctx context.Context, db *mongo.Database, name string, n int) (uint, error) {
+	var result struct {
+		SequenceValue uint `bson:"sequence_value"`
+	}
+
+	err := db.Collection("counters").FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": name},
+		bson.M{"$inc": bson.M{"sequence_value": n}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&result)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.SequenceValue - uint(n) + 1, nil
+}
+
 
@@ -1,50 +1,251 @@
-	package repository
-
-	// import (
-	// 	"fmt"
-	// 	"go.mongodb.org/mongo-driver/bson"
-	// 	"go.mongodb.org/mongo-driver/mongo"
-	// 	"go.mongodb.org/mongo-driver/mongo/options"
-	// 	"strconv"
-	// 	"github.com/gin-gonic/gin"
-	// )
-
-	// // Paginate handles pagination logic (counting documents, calculating links, etc.)
-	// func Paginate(c *gin.Context, db *mongo.Database, filter bson.M, route string) (string, string, int64, error) {
-	// 	page := c.DefaultQuery("page", "1")
-	// 	limit := c.DefaultQuery("limit", "10")
-
-	// 	// Convert page and limit to integers
-	// 	pageInt, err := strconv.Atoi(page)
-	// 	if err != nil || pageInt <= 0 {
-	// 		pageInt = 1
-	// 	}
-
-	// 	limitInt, err := strconv.Atoi(limit)
-	// 	if err != nil || limitInt <= 0 {
-	// 		limitInt = 10
-	// 	}
-
-	// 	// Calculate the skip value (used for pagination in the query)
-	// 	skip := (pageInt - 1) * limitInt
-
-	// 	// Get total count of matching documents for pagination
-	// 	totalCount, err := db.Collection("jobs").CountDocuments(c, filter) // You can use different collection if needed
-	// 	if err != nil {
-	// 		return "", "", 0, err
-	// 	}
-
-	// 	// Pagination links
-	// 	var nextLink, prevLink string
-	// 	pageInt64 := int64(pageInt)
-	// 	limitInt64 := int64(limitInt)
-	// 	if pageInt64*limitInt64 < totalCount {
-	// 		nextLink = fmt.Sprintf("%s?page=%d&limit=%d", route, pageInt+1, limitInt)
-	// 	}
-
-	// 	if pageInt > 1 {
-	// 		prevLink = fmt.Sprintf("%s?page=%d&limit=%d", route, pageInt-1, limitInt)
-	// 	}
-
-	// 	return nextLink, prevLink, totalCount, nil
-	// }
+package repository
+
+import (
+	"RAAS/core/config"
+
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrInvalidCursor is returned for a cursor that was tampered with or belongs to another sort order
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// SortKey is one field of a listing's sort order
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// PageCursor marks the boundary item of a page. Cursors are opaque to clients:
+// the BSON is base64url encoded and signed, see EncodeCursor.
+type PageCursor struct {
+	Sort     string `bson:"s"`           // Signature of the sort order the cursor belongs to
+	Values   bson.A `bson:"v"`           // Sort key values of the boundary item, _id last
+	Backward bool   `bson:"b,omitempty"` // The page before the boundary item rather than after it
+}
+
+// PageInfo is the pagination part of the response envelope
+type PageInfo struct {
+	Limit      int    `json:"limit"`
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	Next       string `json:"next,omitempty"` // Link to the next page, empty on the last one
+	Prev       string `json:"prev,omitempty"`
+}
+
+// Page is a keyset-paginated listing: items are ordered by Sort and _id, and a page starts
+// right after (or ends right before) the cursor's item, so inserts do not shift the pages.
+type Page struct {
+	Sort   []SortKey
+	Limit  int
+	Cursor *PageCursor
+}
+
+// EncodeCursor signs a cursor with HMAC-SHA256, the token is payload.signature in base64url
+func EncodeCursor(cursor PageCursor, secret string) (string, error) {
+	payload, err := bson.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signCursor(payload, secret)), nil
+}
+
+// DecodeCursor verifies the signature of a token and returns its cursor
+func DecodeCursor(token, secret string) (*PageCursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signCursor(payload, secret)) {
+		return nil, ErrInvalidCursor
+	}
+	var cursor PageCursor
+	if err := bson.Unmarshal(payload, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+func signCursor(payload []byte, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// NewPage reads the limit and cursor that PaginationMiddleware stored and fixes the sort order.
+// _id is appended as the last key when missing so that the order is total.
+func NewPage(c *gin.Context, sort ...SortKey) (Page, error) {
+	if len(sort) == 0 || sort[len(sort)-1].Field != "_id" {
+		sort = append(sort, SortKey{Field: "_id"})
+	}
+	pagination := c.MustGet("pagination").(gin.H)
+	page := Page{Sort: sort, Limit: pagination["limit"].(int)}
+	if cursor, ok := pagination["cursor"].(*PageCursor); ok && cursor != nil {
+		if cursor.Sort != page.signature() || len(cursor.Values) != len(sort) {
+			return Page{}, ErrInvalidCursor
+		}
+		page.Cursor = cursor
+	}
+	return page, nil
+}
+
+// signature identifies the sort order, a cursor only works with the order it was issued for
+func (p Page) signature() string {
+	parts := make([]string, 0, len(p.Sort))
+	for _, key := range p.Sort {
+		direction := ":1"
+		if key.Desc {
+			direction = ":-1"
+		}
+		parts = append(parts, key.Field+direction)
+	}
+	return strings.Join(parts, ",")
+}
+
+// backward reports whether the page is read against the sort order
+func (p Page) backward() bool {
+	return p.Cursor != nil && p.Cursor.Backward
+}
+
+// Filter is the keyset condition that starts the page at the cursor, empty on the first page.
+// Missing fields sort before every value, as they do in MongoDB.
+func (p Page) Filter() bson.M {
+	if p.Cursor == nil {
+		return bson.M{}
+	}
+	var branches []bson.M
+	for i, key := range p.Sort {
+		// Everything before key i equals the boundary item, key i is past it
+		branch := bson.M{}
+		for j := 0; j < i; j++ {
+			branch[p.Sort[j].Field] = p.Cursor.Values[j]
+		}
+		value := p.Cursor.Values[i]
+		towardSmaller := key.Desc != p.backward()
+		switch {
+		case value == nil && towardSmaller:
+			continue // nothing sorts before a missing value
+		case value == nil:
+			branch[key.Field] = bson.M{"$ne": nil}
+		case towardSmaller:
+			branch["$or"] = []bson.M{{key.Field: bson.M{"$lt": value}}, {key.Field: nil}}
+		default:
+			branch[key.Field] = bson.M{"$gt": value}
+		}
+		branches = append(branches, branch)
+	}
+	if len(branches) == 0 {
+		return bson.M{"_id": bson.M{"$exists": false}}
+	}
+	return bson.M{"$or": branches}
+}
+
+// SortDocument is the sort of the query, reversed when reading backward
+func (p Page) SortDocument() bson.D {
+	sort := bson.D{}
+	for _, key := range p.Sort {
+		direction := 1
+		if key.Desc != p.backward() {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: key.Field, Value: direction})
+	}
+	return sort
+}
+
+// FindOptions sorts and fetches one item more than the limit, to tell whether another page follows
+func (p Page) FindOptions() *options.FindOptions {
+	return options.Find().SetSort(p.SortDocument()).SetLimit(int64(p.Limit + 1))
+}
+
+// Stages are the aggregation equivalent of Filter and FindOptions, appended after the listing's $match
+func (p Page) Stages() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$match", Value: p.Filter()}},
+		{{Key: "$sort", Value: p.SortDocument()}},
+		{{Key: "$limit", Value: p.Limit + 1}},
+	}
+}
+
+// Trim takes the documents fetched with FindOptions or Stages and returns the page in sort order
+// with the cursors of the neighbouring pages
+func (p Page) Trim(docs []bson.Raw) ([]bson.Raw, PageInfo, error) {
+	info := PageInfo{Limit: p.Limit}
+	more := len(docs) > p.Limit
+	if more {
+		docs = docs[:p.Limit]
+	}
+	if p.backward() {
+		for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+			docs[i], docs[j] = docs[j], docs[i]
+		}
+	}
+	if len(docs) == 0 {
+		return docs, info, nil
+	}
+
+	// Reading forward, a next page exists when an extra item came back and a previous one when
+	// we got here through a cursor; reading backward it is the other way round
+	hasNext, hasPrev := more, p.Cursor != nil
+	if p.backward() {
+		hasNext, hasPrev = true, more
+	}
+	var err error
+	if hasNext {
+		if info.NextCursor, err = p.cursorAt(docs[len(docs)-1], false); err != nil {
+			return nil, PageInfo{}, err
+		}
+	}
+	if hasPrev {
+		if info.PrevCursor, err = p.cursorAt(docs[0], true); err != nil {
+			return nil, PageInfo{}, err
+		}
+	}
+	return docs, info, nil
+}
+
+func (p Page) cursorAt(doc bson.Raw, backward bool) (string, error) {
+	cursor := PageCursor{Sort: p.signature(), Values: bson.A{}, Backward: backward}
+	for _, key := range p.Sort {
+		value, err := doc.LookupErr(strings.Split(key.Field, ".")...)
+		if err != nil {
+			cursor.Values = append(cursor.Values, nil)
+			continue
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+	return EncodeCursor(cursor, config.Cfg.Project.PaginationCursorSecret)
+}
+
+// Paginated is the response envelope of the list endpoints: the items under data and the
+// pagination with links built from the request, so they keep its path and filters
+func Paginated(c *gin.Context, items interface{}, info PageInfo) gin.H {
+	link := func(cursor string) string {
+		if cursor == "" {
+			return ""
+		}
+		query := c.Request.URL.Query()
+		query.Del("offset")
+		query.Set("cursor", cursor)
+		query.Set("limit", strconv.Itoa(info.Limit))
+		return c.Request.URL.Path + "?" + query.Encode()
+	}
+	info.Next = link(info.NextCursor)
+	info.Prev = link(info.PrevCursor)
+	return gin.H{"data": items, "pagination": info}
+}
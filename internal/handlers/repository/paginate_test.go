@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"RAAS/core/config"
+
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCursorSigning(t *testing.T) {
+	cursor := PageCursor{Sort: "score:-1,_id:1", Values: bson.A{1.5, "abc"}, Backward: true}
+	token, err := EncodeCursor(cursor, "secret")
+	if err != nil {
+		t.Fatalf("EncodeCursor: %v", err)
+	}
+
+	decoded, err := DecodeCursor(token, "secret")
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if decoded.Sort != cursor.Sort || !decoded.Backward || len(decoded.Values) != 2 {
+		t.Errorf("decoded cursor = %+v, want %+v", decoded, cursor)
+	}
+
+	tampered := []byte(token)
+	tampered[0] ^= 1
+	for name, bad := range map[string]string{
+		"other secret":  token,
+		"tampered":      string(tampered),
+		"no signature":  token[:len(token)-44],
+		"not base64":    "!!!.!!!",
+		"missing split": "abc",
+	} {
+		secret := "secret"
+		if name == "other secret" {
+			secret = "other"
+		}
+		if _, err := DecodeCursor(bad, secret); err != ErrInvalidCursor {
+			t.Errorf("%s: err = %v, want ErrInvalidCursor", name, err)
+		}
+	}
+}
+
+func TestPageTrim(t *testing.T) {
+	config.Cfg = &config.Config{Project: &config.ProjectConfig{PaginationCursorSecret: "secret"}}
+	page := Page{Sort: JobSearchSort(), Limit: 2}
+	doc := func(id string, score float64) bson.Raw {
+		raw, _ := bson.Marshal(bson.D{{Key: "_id", Value: id}, {Key: "score", Value: score}, {Key: "posted_date", Value: "2026-10-01"}})
+		return raw
+	}
+
+	docs, info, err := page.Trim([]bson.Raw{doc("a", 3), doc("b", 2), doc("c", 1)})
+	if err != nil {
+		t.Fatalf("Trim: %v", err)
+	}
+	if len(docs) != 2 || info.NextCursor == "" || info.PrevCursor != "" {
+		t.Fatalf("first page: %d docs, next %q, prev %q", len(docs), info.NextCursor, info.PrevCursor)
+	}
+	next, err := DecodeCursor(info.NextCursor, "secret")
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if next.Sort != page.signature() || next.Backward {
+		t.Errorf("next cursor = %+v", next)
+	}
+	if got := next.Values[2]; got != "b" {
+		t.Errorf("next cursor boundary = %v, want b", got)
+	}
+
+	// Reading backward the documents come in reverse order and the page is flipped back
+	page.Cursor = &PageCursor{Sort: page.signature(), Values: bson.A{1.0, "2026-10-01", "c"}, Backward: true}
+	docs, info, err = page.Trim([]bson.Raw{doc("b", 2), doc("a", 3)})
+	if err != nil {
+		t.Fatalf("Trim: %v", err)
+	}
+	var ids []string
+	for _, d := range docs {
+		ids = append(ids, d.Lookup("_id").StringValue())
+	}
+	if !reflect.DeepEqual(ids, []string{"a", "b"}) || info.NextCursor == "" || info.PrevCursor != "" {
+		t.Errorf("backward page = %v, next %q, prev %q", ids, info.NextCursor, info.PrevCursor)
+	}
+}
+
+func TestPageSortDocument(t *testing.T) {
+	page := Page{Sort: JobSearchSort(), Limit: 10}
+	want := bson.D{{Key: "score", Value: -1}, {Key: "posted_date", Value: -1}, {Key: "_id", Value: 1}}
+	if got := page.SortDocument(); !reflect.DeepEqual(got, want) {
+		t.Errorf("forward sort = %v, want %v", got, want)
+	}
+	page.Cursor = &PageCursor{Backward: true}
+	want = bson.D{{Key: "score", Value: 1}, {Key: "posted_date", Value: 1}, {Key: "_id", Value: -1}}
+	if got := page.SortDocument(); !reflect.DeepEqual(got, want) {
+		t.Errorf("backward sort = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MaxPersonas caps the number of personas per seeker
+const MaxPersonas = 5
+
+var ErrPersonaNotFound = errors.New("persona not found")
+
+// FindPersona returns the persona with the given id and its position in the seeker's list
+func FindPersona(seeker *models.Seeker, personaID string) (*models.Persona, int) {
+	for i := range seeker.Personas {
+		if seeker.Personas[i].PersonaID == personaID {
+			return &seeker.Personas[i], i
+		}
+	}
+	return nil, -1
+}
+
+// ApplyPersona returns a copy of the seeker with the persona layered on top.
+// An empty personaID returns the base seeker unchanged.
+func ApplyPersona(seeker models.Seeker, personaID string) (models.Seeker, error) {
+	if personaID == "" {
+		return seeker, nil
+	}
+	persona, _ := FindPersona(&seeker, personaID)
+	if persona == nil {
+		return seeker, ErrPersonaNotFound
+	}
+
+	seeker.PrimaryTitle = persona.PrimaryTitle
+	seeker.SecondaryTitle = persona.SecondaryTitle
+	seeker.TertiaryTitle = persona.TertiaryTitle
+
+	// Copy the summary so the overrides never leak into the base document
+	summary := bson.M{}
+	for k, v := range seeker.ProfessionalSummary {
+		summary[k] = v
+	}
+	if persona.Summary != nil {
+		summary["about"] = *persona.Summary
+	}
+	if len(persona.Skills) > 0 {
+		skills := make([]interface{}, len(persona.Skills))
+		for i, skill := range persona.Skills {
+			skills[i] = skill
+		}
+		summary["skills"] = bson.A(skills)
+	}
+	seeker.ProfessionalSummary = summary
+
+	// References to work experiences deleted since are dropped; when none is left the base list is kept
+	if ids := LiveWorkExperienceIDs(&seeker, persona.WorkExperienceIDs); len(ids) > 0 {
+		experiences := []bson.M{}
+		for _, id := range ids {
+			experiences = append(experiences, seeker.WorkExperiences[FindWorkExperience(&seeker, id)])
+		}
+		seeker.WorkExperiences = experiences
+	}
+
+	return seeker, nil
+}
+
+// FindWorkExperience returns the position of the work experience with the given id, or -1
+func FindWorkExperience(seeker *models.Seeker, id string) int {
+	for i, exp := range seeker.WorkExperiences {
+		if entryID, _ := exp["id"].(string); entryID != "" && entryID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// LiveWorkExperienceIDs returns the ids that still match a work experience of the seeker, in order
+func LiveWorkExperienceIDs(seeker *models.Seeker, ids []string) []string {
+	live := []string{}
+	for _, id := range ids {
+		if FindWorkExperience(seeker, id) >= 0 {
+			live = append(live, id)
+		}
+	}
+	return live
+}
+
+// AssignWorkExperienceIDs gives an id to the work experiences stored before entries had one and
+// turns the index references of personas into id references. It returns the number of seekers updated.
+func AssignWorkExperienceIDs(ctx context.Context, db *mongo.Database) (int, error) {
+	collection := db.Collection("seekers")
+	cursor, err := collection.Find(ctx, bson.M{"$or": bson.A{
+		bson.M{"work_experiences": bson.M{"$elemMatch": bson.M{"id": bson.M{"$exists": false}}}},
+		bson.M{"personas.work_experience_indexes": bson.M{"$exists": true}},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var seeker struct {
+			AuthUserID      string   `bson:"auth_user_id"`
+			Version         int64    `bson:"version"`
+			WorkExperiences []bson.M `bson:"work_experiences"`
+			Personas        []bson.M `bson:"personas"`
+		}
+		if err := cursor.Decode(&seeker); err != nil {
+			continue
+		}
+
+		ids := make([]string, len(seeker.WorkExperiences))
+		for i, exp := range seeker.WorkExperiences {
+			id, _ := exp["id"].(string)
+			if id == "" {
+				id = primitive.NewObjectID().Hex()
+				exp["id"] = id
+			}
+			ids[i] = id
+		}
+		for _, persona := range seeker.Personas {
+			indexes, ok := persona["work_experience_indexes"].(bson.A)
+			if !ok {
+				delete(persona, "work_experience_indexes")
+				continue
+			}
+			referenced := []string{}
+			for _, value := range indexes {
+				var index int
+				switch v := value.(type) {
+				case int32:
+					index = int(v)
+				case int64:
+					index = int(v)
+				case float64:
+					index = int(v)
+				default:
+					continue
+				}
+				if index >= 0 && index < len(ids) {
+					referenced = append(referenced, ids[index])
+				}
+			}
+			delete(persona, "work_experience_indexes")
+			if len(referenced) > 0 {
+				persona["work_experience_ids"] = referenced
+			}
+		}
+
+		set := bson.M{"work_experiences": seeker.WorkExperiences}
+		if seeker.Personas != nil {
+			set["personas"] = seeker.Personas
+		}
+		// A seeker edited meanwhile is picked up again on the next start
+		result, err := collection.UpdateOne(ctx, VersionFilter(seeker.AuthUserID, seeker.Version), BumpVersion(bson.M{"$set": set}))
+		if err != nil {
+			return updated, err
+		}
+		updated += int(result.ModifiedCount)
+	}
+	return updated, cursor.Err()
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestApplyPersonaWorkExperienceIDs(t *testing.T) {
+	seeker := models.Seeker{
+		WorkExperiences: []bson.M{
+			{"id": "a", "job_title": "Backend Developer"},
+			{"id": "b", "job_title": "Team Lead"},
+			{"job_title": "Intern"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		ids   []string
+		title []string
+	}{
+		{"no override keeps every experience", nil, []string{"Backend Developer", "Team Lead", "Intern"}},
+		{"ids select in persona order", []string{"b", "a"}, []string{"Team Lead", "Backend Developer"}},
+		{"dangling ids are dropped", []string{"deleted", "b"}, []string{"Team Lead"}},
+		{"only dangling ids fall back to the base list", []string{"deleted"}, []string{"Backend Developer", "Team Lead", "Intern"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := seeker
+			s.Personas = []models.Persona{{PersonaID: "p", PrimaryTitle: "Developer", WorkExperienceIDs: tt.ids}}
+			applied, err := ApplyPersona(s, "p")
+			if err != nil {
+				t.Fatalf("ApplyPersona: %v", err)
+			}
+			var titles []string
+			for _, exp := range applied.WorkExperiences {
+				titles = append(titles, exp["job_title"].(string))
+			}
+			if !reflect.DeepEqual(titles, tt.title) {
+				t.Errorf("work experiences = %v, want %v", titles, tt.title)
+			}
+		})
+	}
+
+	if _, err := ApplyPersona(seeker, "missing"); err != ErrPersonaNotFound {
+		t.Errorf("unknown persona: err = %v, want ErrPersonaNotFound", err)
+	}
+}
+
+func TestLiveWorkExperienceIDs(t *testing.T) {
+	seeker := &models.Seeker{WorkExperiences: []bson.M{{"id": "a"}, {"id": "b"}}}
+	got := LiveWorkExperienceIDs(seeker, []string{"b", "gone", "a"})
+	if want := []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LiveWorkExperienceIDs = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/models"
+
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ProfileUpdate is a validated PUT /profile: the seeker fields to set and the timeline flags that go with them
+type ProfileUpdate struct {
+	Set      bson.M
+	Timeline bson.M
+	Sections []string
+	Warnings []string
+}
+
+// BuildProfileUpdate validates every section of a bulk profile update against the stored seeker.
+// All field errors are collected, prefixed with their section ("work_experiences[1].start_date").
+func BuildProfileUpdate(ctx context.Context, db *mongo.Database, seeker *models.Seeker, input dto.ProfileUpdateRequest, now time.Time) (ProfileUpdate, []dto.FieldError) {
+	update := ProfileUpdate{Set: bson.M{}, Timeline: bson.M{}}
+	var errs []dto.FieldError
+	scratch := &models.Seeker{Certificates: seeker.Certificates}
+
+	if input.PersonalInfo != nil {
+		if err := SetPersonalInfo(scratch, input.PersonalInfo); err != nil {
+			errs = append(errs, dto.FieldError{Field: "personal_info", Message: err.Error()})
+		}
+		update.Set["personal_info"] = scratch.PersonalInfo
+		update.Timeline["personal_infos_completed"] = true
+		update.Sections = append(update.Sections, "personal_info")
+	}
+
+	if input.ProfessionalSummary != nil {
+		input.ProfessionalSummary.Skills = NormalizeSkills(ctx, db, input.ProfessionalSummary.Skills)
+		if err := SetProfessionalSummary(scratch, input.ProfessionalSummary); err != nil {
+			errs = append(errs, dto.FieldError{Field: "professional_summary", Message: err.Error()})
+		}
+		update.Set["professional_summary"] = scratch.ProfessionalSummary
+		update.Timeline["professional_summaries_completed"] = true
+		update.Sections = append(update.Sections, "professional_summary")
+	}
+
+	if input.WorkExperiences != nil {
+		scratch.WorkExperiences = []bson.M{}
+		for i, exp := range *input.WorkExperiences {
+			exp.EndDate = NormalizeEndDate(exp.EndDate)
+			errs = append(errs, prefixFieldErrors(fmt.Sprintf("work_experiences[%d]", i), ValidateDateRange(WorkDateRange, exp.StartDate, exp.EndDate, now))...)
+			update.Warnings = append(update.Warnings, WorkOverlapWarnings(scratch.WorkExperiences, exp.EmploymentType, exp.StartDate, exp.EndDate)...)
+			if exp.ID != "" && FindWorkExperience(seeker, exp.ID) < 0 {
+				errs = append(errs, dto.FieldError{Field: fmt.Sprintf("work_experiences[%d].id", i), Message: "does not match an existing work experience"})
+				continue
+			}
+			AppendToWorkExperience(scratch, exp)
+		}
+		update.Set["work_experiences"] = scratch.WorkExperiences
+		update.Timeline["work_experiences_completed"] = len(scratch.WorkExperiences) > 0
+		update.Sections = append(update.Sections, "work_experiences")
+	}
+
+	if input.Education != nil {
+		scratch.Education = []bson.M{}
+		for i, education := range *input.Education {
+			education.EndDate = NormalizeEndDate(education.EndDate)
+			errs = append(errs, prefixFieldErrors(fmt.Sprintf("education[%d]", i), ValidateDateRange(EducationDateRange, education.StartDate, education.EndDate, now))...)
+			AppendToEducation(scratch, education)
+		}
+		update.Set["education"] = scratch.Education
+		update.Timeline["educations_completed"] = len(scratch.Education) > 0
+		update.Sections = append(update.Sections, "education")
+	}
+
+	if input.Languages != nil {
+		// Uploaded certificate files stay with their language, they cannot be set through JSON
+		files := map[string]string{}
+		for _, existing := range seeker.Languages {
+			if file, _ := existing["certificate_file"].(string); file != "" {
+				files[LanguageCodeOf(existing)] = file
+			}
+		}
+		scratch.Languages = []bson.M{}
+		for i, language := range *input.Languages {
+			code, _, _ := ResolveLanguage(language.LanguageName)
+			if err := AppendToLanguages(scratch, language, files[code]); err != nil {
+				errs = append(errs, dto.FieldError{Field: fmt.Sprintf("languages[%d]", i), Message: err.Error()})
+			}
+		}
+		update.Set["languages"] = scratch.Languages
+		update.Timeline["languages_completed"] = len(scratch.Languages) > 0
+		update.Sections = append(update.Sections, "languages")
+	}
+
+	if input.JobPreferences != nil {
+		preferences, err := NormalizeJobPreferences(*input.JobPreferences)
+		if err != nil {
+			errs = append(errs, dto.FieldError{Field: "job_preferences", Message: err.Error()})
+		}
+		update.Set["job_preferences"] = preferences
+		update.Timeline["job_preferences_completed"] = true
+		update.Sections = append(update.Sections, "job_preferences")
+	}
+
+	if input.Projects != nil {
+		projects := []models.Project{}
+		for i, item := range *input.Projects {
+			project, projectErrs := BuildProject(ctx, db, item.ProjectRequest, now)
+			errs = append(errs, prefixFieldErrors(fmt.Sprintf("projects[%d]", i), projectErrs)...)
+
+			project.ID = primitive.NewObjectID()
+			project.CreatedAt = now
+			if item.ID != "" {
+				index := FindProject(seeker, item.ID)
+				if index < 0 {
+					errs = append(errs, dto.FieldError{Field: fmt.Sprintf("projects[%d].id", i), Message: "does not match an existing project"})
+					continue
+				}
+				project.ID = seeker.Projects[index].ID
+				project.CreatedAt = seeker.Projects[index].CreatedAt
+			}
+			projects = append(projects, project)
+		}
+		update.Set["projects"] = projects
+		update.Timeline["projects_completed"] = len(projects) > 0
+		update.Sections = append(update.Sections, "projects")
+	}
+
+	if input.Tools != nil {
+		update.Set["tools"] = NormalizeTools(ctx, db, *input.Tools)
+		update.Sections = append(update.Sections, "tools")
+	}
+
+	return update, errs
+}
+
+// ApplyProfileUpdate writes the seeker sections and refreshes the timeline flags in one transaction,
+// so the profile and its timeline never disagree. The seeker is only updated at the expected version,
+// otherwise ErrVersionConflict is returned and nothing is written.
+func ApplyProfileUpdate(ctx context.Context, db *mongo.Database, userID string, version int64, update ProfileUpdate) (int64, error) {
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return 0, err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		result, err := db.Collection("seekers").UpdateOne(sessCtx, VersionFilter(userID, version), BumpVersion(bson.M{"$set": update.Set}))
+		if err != nil {
+			return nil, err
+		}
+		if result.MatchedCount == 0 {
+			return nil, ErrVersionConflict
+		}
+
+		// Read inside the transaction, the timeline is evaluated against the sections just written
+		if len(update.Timeline) > 0 {
+			if err := RefreshTimeline(sessCtx, db, userID, update.Timeline); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			return 0, ErrVersionConflict
+		}
+		return 0, err
+	}
+	return version + 1, nil
+}
+
+func prefixFieldErrors(prefix string, errs []dto.FieldError) []dto.FieldError {
+	for i := range errs {
+		errs[i].Field = prefix + "." + errs[i].Field
+	}
+	return errs
+}
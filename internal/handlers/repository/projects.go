@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/models"
+
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MaxProjects caps the portfolio entries of a seeker
+const MaxProjects = 30
+
+// BuildProject validates a project request and normalizes its tech stack against the skills taxonomy.
+// Dates follow the work experience rules; an end date needs a start date.
+func BuildProject(ctx context.Context, db *mongo.Database, input dto.ProjectRequest, now time.Time) (models.Project, []dto.FieldError) {
+	project := models.Project{
+		Title:       strings.TrimSpace(input.Title),
+		Role:        strings.TrimSpace(input.Role),
+		Description: strings.TrimSpace(input.Description),
+		TechStack:   NormalizeSkills(ctx, db, input.TechStack),
+		Links:       uniqueStrings(input.Links, nil),
+		UpdatedAt:   now,
+	}
+	if project.Title == "" {
+		return project, []dto.FieldError{{Field: "title", Message: "is required"}}
+	}
+
+	end := NormalizeEndDate(input.EndDate)
+	if input.StartDate == nil || input.StartDate.Time.IsZero() {
+		if end != nil {
+			return project, []dto.FieldError{{Field: "start_date", Message: "is required when end_date is set"}}
+		}
+		return project, nil
+	}
+	if errs := ValidateDateRange(WorkDateRange, *input.StartDate, end, now); len(errs) > 0 {
+		return project, errs
+	}
+	start := input.StartDate.Time
+	project.StartDate = &start
+	if end != nil {
+		project.EndDate = &end.Time
+	}
+	return project, nil
+}
+
+// FindProject returns the index of a project in the seeker's portfolio, or -1
+func FindProject(seeker *models.Seeker, projectID string) int {
+	id, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return -1
+	}
+	for i, project := range seeker.Projects {
+		if project.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// NormalizeTools cleans the tools list the same way as skills
+func NormalizeTools(ctx context.Context, db *mongo.Database, tools []string) []string {
+	return NormalizeSkills(ctx, db, tools)
+}
+
+// PortfolioEntries renders the projects for the generation payloads, one line per project:
+// "Title (Role, 2023-01 – present): description. Tech: Go, React. Links: https://..."
+func PortfolioEntries(seeker *models.Seeker) []string {
+	entries := []string{}
+	for _, project := range seeker.Projects {
+		var meta []string
+		if project.Role != "" {
+			meta = append(meta, project.Role)
+		}
+		if project.StartDate != nil {
+			period := project.StartDate.Format("2006-01") + " – present"
+			if project.EndDate != nil {
+				period = project.StartDate.Format("2006-01") + " – " + project.EndDate.Format("2006-01")
+			}
+			meta = append(meta, period)
+		}
+
+		entry := project.Title
+		if len(meta) > 0 {
+			entry += fmt.Sprintf(" (%s)", strings.Join(meta, ", "))
+		}
+		if project.Description != "" {
+			entry += ": " + project.Description
+		}
+		if len(project.TechStack) > 0 {
+			entry += ". Tech: " + strings.Join(project.TechStack, ", ")
+		}
+		if len(project.Links) > 0 {
+			entry += ". Links: " + strings.Join(project.Links, " ")
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// ToolsSummary renders the tools list as the comma-separated string the generation API expects
+func ToolsSummary(seeker *models.Seeker) string {
+	return strings.Join(seeker.Tools, ", ")
+}
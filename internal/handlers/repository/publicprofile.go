@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"RAAS/internal/dto"
+	"RAAS/internal/models"
+
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// MaxActiveProfileShares caps the share links a seeker can have open at once
+	MaxActiveProfileShares = 10
+	// DefaultProfileShareDays is the lifetime of a share link created without expires_in_days
+	DefaultProfileShareDays = 30
+)
+
+var (
+	ErrShareNotFound = errors.New("share link not found")
+	ErrShareExpired  = errors.New("share link expired")
+)
+
+var slugEncoding = base32.NewEncoding("abcdefghijkmnpqrstuvwxyz23456789").WithPadding(base32.NoPadding)
+
+// NewShareSlug returns a random, unguessable slug for /p/:slug
+func NewShareSlug() (string, error) {
+	buf := make([]byte, 10)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return slugEncoding.EncodeToString(buf), nil
+}
+
+// ProfileVisibilityOf returns the seeker's visibility settings, or the defaults when none are saved
+func ProfileVisibilityOf(seeker *models.Seeker) models.ProfileVisibility {
+	if seeker.ProfileVisibility == nil {
+		return models.DefaultProfileVisibility()
+	}
+	return *seeker.ProfileVisibility
+}
+
+// ApplyVisibilityRequest overwrites the settings the request carries
+func ApplyVisibilityRequest(visibility models.ProfileVisibility, input dto.ProfileVisibilityRequest) models.ProfileVisibility {
+	for _, field := range []struct {
+		value  *bool
+		target *bool
+	}{
+		{input.Photo, &visibility.Photo},
+		{input.Summary, &visibility.Summary},
+		{input.Skills, &visibility.Skills},
+		{input.WorkExperience, &visibility.WorkExperience},
+		{input.Education, &visibility.Education},
+		{input.Certificates, &visibility.Certificates},
+		{input.Languages, &visibility.Languages},
+		{input.Contact, &visibility.Contact},
+	} {
+		if field.value != nil {
+			*field.target = *field.value
+		}
+	}
+	return visibility
+}
+
+// FindProfileShare loads the share behind a slug. Revoked links are reported as not found.
+func FindProfileShare(ctx context.Context, db *mongo.Database, slug string) (models.ProfileShare, error) {
+	var share models.ProfileShare
+	if err := db.Collection("profile_shares").FindOne(ctx, bson.M{"slug": slug}).Decode(&share); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return share, ErrShareNotFound
+		}
+		return share, err
+	}
+	if share.RevokedAt != nil {
+		return share, ErrShareNotFound
+	}
+	if !share.Active(time.Now()) {
+		return share, ErrShareExpired
+	}
+	return share, nil
+}
+
+// RecordShareView counts a visit of the public profile
+func RecordShareView(ctx context.Context, db *mongo.Database, slug string) error {
+	_, err := db.Collection("profile_shares").UpdateOne(ctx,
+		bson.M{"slug": slug},
+		bson.M{"$inc": bson.M{"views": 1}, "$set": bson.M{"last_viewed_at": time.Now()}},
+	)
+	return err
+}
+
+// BuildPublicProfile maps the seeker document onto the public profile, keeping only the visible sections
+func BuildPublicProfile(seeker *models.Seeker, authUser *models.AuthUser, visibility models.ProfileVisibility) dto.PublicProfileDTO {
+	profile := dto.PublicProfileDTO{
+		Name:     strings.TrimSpace(stringField(seeker.PersonalInfo, "first_name") + " " + stringField(seeker.PersonalInfo, "second_name")),
+		Headline: seeker.PrimaryTitle,
+	}
+
+	if visibility.Photo && seeker.ProfilePhoto != nil {
+		profile.PhotoURL = seeker.ProfilePhoto.URL
+	}
+	if visibility.Summary {
+		profile.Summary = stringField(seeker.ProfessionalSummary, "about")
+	}
+	if visibility.Skills {
+		profile.Skills = SeekerSkills(*seeker)
+	}
+
+	if visibility.WorkExperience {
+		profile.TotalExperienceInMonths = TotalExperienceMonths(seeker.WorkExperiences, time.Now())
+		for _, exp := range seeker.WorkExperiences {
+			profile.WorkExperiences = append(profile.WorkExperiences, dto.PublicWorkExperience{
+				JobTitle:       stringField(exp, "job_title"),
+				CompanyName:    stringField(exp, "company_name"),
+				EmploymentType: stringField(exp, "employment_type"),
+				StartDate:      FormatStoredDate(exp["start_date"]),
+				EndDate:        FormatStoredDate(exp["end_date"]),
+			})
+		}
+	}
+
+	if visibility.Education {
+		for _, edu := range seeker.Education {
+			profile.Education = append(profile.Education, dto.PublicEducation{
+				Degree:       stringField(edu, "degree"),
+				Institution:  stringField(edu, "institution"),
+				FieldOfStudy: stringField(edu, "field_of_study"),
+				StartDate:    FormatStoredDate(edu["start_date"]),
+				EndDate:      FormatStoredDate(edu["end_date"]),
+			})
+		}
+	}
+
+	if visibility.Certificates {
+		for _, cert := range seeker.Certificates {
+			if name := stringField(cert, "certificate_name"); name != "" {
+				profile.Certificates = append(profile.Certificates, name)
+			}
+		}
+	}
+
+	if visibility.Languages {
+		for _, lang := range seeker.Languages {
+			profile.Languages = append(profile.Languages, dto.PublicLanguage{
+				Language:    stringField(lang, "language"),
+				Proficiency: stringField(lang, "proficiency"),
+			})
+		}
+	}
+
+	if visibility.Contact {
+		profile.Contact = &dto.PublicContact{
+			LinkedIn: stringField(seeker.PersonalInfo, "linkedin_profile"),
+		}
+		if authUser != nil {
+			profile.Contact.Email = authUser.Email
+			profile.Contact.Phone = authUser.Phone
+		}
+	}
+
+	return profile
+}
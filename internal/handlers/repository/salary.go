@@ -0,0 +1,502 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Salary periods of a posting
+const (
+	SalaryPerHour  = "hour"
+	SalaryPerMonth = "month"
+	SalaryPerYear  = "year"
+)
+
+// Seniority levels derived from job titles
+const (
+	SeniorityIntern = "intern"
+	SeniorityJunior = "junior"
+	SeniorityMid    = "mid"
+	SenioritySenior = "senior"
+	SeniorityLead   = "lead"
+)
+
+// Full-time hours per year, to turn an hourly rate into a yearly salary
+const hoursPerYear = 40 * 52
+
+// Yearly salaries outside this range are taken for something else (head counts, funding, years)
+const (
+	minPlausibleSalary = 5000
+	maxPlausibleSalary = 1000000
+)
+
+// An estimate needs this many stated salaries of similar jobs
+const minSalarySamples = 3
+
+// salaryAmount matches an amount or a range with optional currency and k notation:
+// "€50k-70k", "50.000 - 70.000 EUR", "$120,000 to $150,000", "25 €", "4.500 bis 5.500 Euro"
+var salaryAmount = regexp.MustCompile(`(?i)(€|\$|£|\beur\b|\busd\b|\bgbp\b|\bchf\b)?\s?(\d{1,3}(?:[.,' ]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?)\s?(k\b)?\s?(€|\beur\b|\beuros?\b|\busd\b|\bgbp\b|\bchf\b)?` +
+	`(?:\s*(?:-|–|—|\bto\b|\bbis\b|\band\b|\bund\b)\s*(€|\$|£|\beur\b|\busd\b|\bgbp\b|\bchf\b)?\s?(\d{1,3}(?:[.,' ]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?)\s?(k\b)?\s?(€|\beur\b|\beuros?\b|\busd\b|\bgbp\b|\bchf\b)?)?`)
+
+var (
+	salaryKeyword  = regexp.MustCompile(`(?i)salary|compensation|\bpay\b|gehalt|vergütung|verdienst|stundenlohn|\blohn\b`)
+	salaryPerHour  = regexp.MustCompile(`(?i)^\W{0,3}(?:(?:per|an|a|pro|/)\s?(?:hour|hr|h|std|stunde)\b|hourly|stündlich)`)
+	salaryPerMonth = regexp.MustCompile(`(?i)^\W{0,3}(?:(?:brutto\s)?(?:per|a|pro|/|im)\s?(?:month|mo|monat)\b|monthly|monatlich|mtl)`)
+	salaryPerYear  = regexp.MustCompile(`(?i)^\W{0,3}(?:(?:brutto\s)?(?:per|a|pro|/|im)\s?(?:year|yr|annum|jahr)\b|p\.\s?a\.|annual|yearly|jährlich)`)
+	// An amount followed by one of these counts something else: "5 years", "3 Tage", "30%", "40 hours per week"
+	notSalaryUnit = regexp.MustCompile(`(?i)^\s?\+?\s?(?:(?:years?|yrs?|jahre?n?|months?|monate?n?|weeks?|wochen?|days?|tage?n?|hours?|hrs?|stunden|std)\b|%|prozent\b|percent\b)`)
+	// Millions and billions are funding rounds or revenue: "$20M", "1,5 Mio. €", "2 Mrd"
+	salaryLargeUnit = regexp.MustCompile(`(?i)^\s?(?:mm?|mio|mrd|bn|b|million|millionen|milliarden?|billion)\b`)
+	// Money that is not pay: "learning budget of €1.000", "we raised $5M", "€2k signing bonus"
+	notSalaryContext = regexp.MustCompile(`(?i)bonus|budget|funding|funded|raised|revenue|turnover|umsatz|investment|valuation|prämie|zuschuss|finanzierung`)
+	notSalaryAfter   = regexp.MustCompile(`(?i)^\W{0,3}(?:(?:signing|sign-on|annual|yearly|referral|relocation|jährliche\w*)\s)?(?:bonus|budget|prämie|zuschuss)`)
+	titleSeniority   = []struct {
+		level   string
+		pattern *regexp.Regexp
+	}{
+		{SeniorityIntern, regexp.MustCompile(`(?i)\b(intern|internship|praktikant\w*|praktikum|werkstudent\w*|working student)\b`)},
+		{SeniorityLead, regexp.MustCompile(`(?i)\b(lead|head|principal|staff|director|chief|vp|manager)\b`)},
+		{SenioritySenior, regexp.MustCompile(`(?i)\b(senior|sr\.?|expert|experienced)\b`)},
+		{SeniorityJunior, regexp.MustCompile(`(?i)\b(junior|jr\.?|entry[- ]level|graduate|trainee|einsteiger)\b`)},
+	}
+	currencyCodes = map[string]string{"€": "EUR", "eur": "EUR", "euro": "EUR", "euros": "EUR", "$": "USD", "usd": "USD", "£": "GBP", "gbp": "GBP", "chf": "CHF"}
+)
+
+// JobSeniority reads the seniority level from a job title, mid when it names none
+func JobSeniority(title string) string {
+	for _, candidate := range titleSeniority {
+		if candidate.pattern.MatchString(title) {
+			return candidate.level
+		}
+	}
+	return SeniorityMid
+}
+
+// ExtractSalary finds the first salary stated in a job description and returns it as a yearly range.
+// An amount with a currency counts when it follows a salary keyword, is a range or has a k suffix or a
+// period; one without a currency needs the keyword and a k suffix or a period. Amounts of years, days,
+// hours, percent or millions never count, nor do bonuses, budgets and funding. Hourly and monthly
+// amounts are converted, and amounts without a period are read by their size (a rate, a monthly or
+// a yearly salary).
+func ExtractSalary(description string) *models.SalaryRange {
+	for _, match := range salaryAmount.FindAllStringSubmatchIndex(description, -1) {
+		group := func(i int) string {
+			if match[2*i] < 0 {
+				return ""
+			}
+			return description[match[2*i]:match[2*i+1]]
+		}
+
+		currency := ""
+		for _, i := range []int{1, 4, 5, 8} {
+			if code, ok := currencyCodes[strings.ToLower(group(i))]; ok {
+				currency = code
+				break
+			}
+		}
+		after := description[match[1]:min(len(description), match[1]+30)]
+		if notSalaryUnit.MatchString(after) || salaryLargeUnit.MatchString(after) || notSalaryAfter.MatchString(after) {
+			continue
+		}
+		before := description[max(0, match[0]-40):match[0]]
+		keyword, otherMoney := salaryContextBefore(before)
+		if otherMoney {
+			continue
+		}
+		period := statedSalaryPeriod(after)
+		thousands := group(3) != "" || group(7) != ""
+		if currency == "" {
+			// A bare number near "salary" is too often a head count or a number of years
+			if !keyword || (!thousands && period == "") {
+				continue
+			}
+		} else if !keyword && !thousands && period == "" && group(6) == "" {
+			// A lone "1000 €" is as likely a bonus or a price as a salary
+			continue
+		}
+
+		low, ok := parseSalaryNumber(group(2), group(3) != "")
+		if !ok {
+			continue
+		}
+		high := low
+		if group(6) != "" {
+			if high, ok = parseSalaryNumber(group(6), group(7) != ""); !ok {
+				continue
+			}
+			// "50-70k" puts the k on the upper bound only
+			if group(3) == "" && group(7) != "" && low < 1000 {
+				low *= 1000
+			}
+		}
+		if high < low {
+			low, high = high, low
+		}
+
+		if period == "" {
+			period = guessSalaryPeriod(high)
+		}
+		switch period {
+		case SalaryPerHour:
+			low, high = low*hoursPerYear, high*hoursPerYear
+		case SalaryPerMonth:
+			low, high = low*12, high*12
+		}
+		if low < minPlausibleSalary || high > maxPlausibleSalary {
+			continue
+		}
+		return &models.SalaryRange{
+			Min:      int(math.Round(low)),
+			Max:      int(math.Round(high)),
+			Currency: currency,
+			Period:   period,
+			Source:   models.SalarySourcePosting,
+		}
+	}
+	return nil
+}
+
+// salaryContextBefore reads the text before an amount: whether it names a salary, and whether a
+// bonus, budget or funding is named after the last salary keyword, so the amount is about that
+func salaryContextBefore(before string) (bool, bool) {
+	lastKeyword := -1
+	if keywords := salaryKeyword.FindAllStringIndex(before, -1); len(keywords) > 0 {
+		lastKeyword = keywords[len(keywords)-1][0]
+	}
+	for _, context := range notSalaryContext.FindAllStringIndex(before, -1) {
+		if context[0] > lastKeyword {
+			return false, true
+		}
+	}
+	return lastKeyword >= 0, false
+}
+
+// statedSalaryPeriod reads the period after an amount, empty when none is stated
+func statedSalaryPeriod(after string) string {
+	switch {
+	case salaryPerHour.MatchString(after):
+		return SalaryPerHour
+	case salaryPerMonth.MatchString(after):
+		return SalaryPerMonth
+	case salaryPerYear.MatchString(after):
+		return SalaryPerYear
+	}
+	return ""
+}
+
+// guessSalaryPeriod reads the period of an amount with a currency but no period from its size
+func guessSalaryPeriod(amount float64) string {
+	switch {
+	case amount < 250:
+		return SalaryPerHour
+	case amount < 15000:
+		return SalaryPerMonth
+	default:
+		return SalaryPerYear
+	}
+}
+
+// parseSalaryNumber reads "50.000", "50,000", "65 000", "4.500,50" and "52,5" (with k: 52500)
+func parseSalaryNumber(value string, thousands bool) (float64, bool) {
+	value = strings.NewReplacer(" ", "", "'", "").Replace(value)
+	if i := strings.LastIndexAny(value, ".,"); i >= 0 {
+		if len(value)-i-1 == 3 {
+			// Only thousands separators
+			value = strings.NewReplacer(".", "", ",", "").Replace(value)
+		} else {
+			// The last separator is the decimal one
+			value = strings.NewReplacer(".", "", ",", "").Replace(value[:i]) + "." + value[i+1:]
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	if thousands {
+		number *= 1000
+	}
+	return number, true
+}
+
+// NormalizeProvidedSalary checks a salary sent by the scraper, nil when it is unusable
+func NormalizeProvidedSalary(salary *models.SalaryRange) *models.SalaryRange {
+	if salary == nil || (salary.Min <= 0 && salary.Max <= 0) {
+		return nil
+	}
+	normalized := models.SalaryRange{
+		Min:      salary.Min,
+		Max:      salary.Max,
+		Currency: strings.ToUpper(strings.TrimSpace(salary.Currency)),
+		Period:   strings.ToLower(strings.TrimSpace(salary.Period)),
+		Source:   models.SalarySourceProvided,
+	}
+	if normalized.Min <= 0 {
+		normalized.Min = normalized.Max
+	}
+	if normalized.Max < normalized.Min {
+		normalized.Max = normalized.Min
+	}
+	switch normalized.Period {
+	case SalaryPerHour:
+		normalized.Min, normalized.Max = normalized.Min*hoursPerYear, normalized.Max*hoursPerYear
+	case SalaryPerMonth:
+		normalized.Min, normalized.Max = normalized.Min*12, normalized.Max*12
+	default:
+		normalized.Period = SalaryPerYear
+	}
+	return &normalized
+}
+
+// JobExpectedSalary is the salary shown for a job: stated, else estimated, else empty
+func JobExpectedSalary(job models.Job) models.SalaryRange {
+	if job.Salary != nil {
+		return *job.Salary
+	}
+	if job.SalaryEstimate != nil {
+		return *job.SalaryEstimate
+	}
+	return models.SalaryRange{}
+}
+
+// SalaryEstimator estimates salaries from the stated salaries of similar jobs. It tries the same title,
+// city and seniority first, then title and seniority, then the title alone, with falling confidence.
+type SalaryEstimator struct {
+	groups map[string][]models.SalaryRange
+}
+
+// salaryEstimateLevels are the grouping keys from most to least specific, with their highest confidence
+var salaryEstimateLevels = []struct {
+	key        func(title, city, seniority string) string
+	confidence float64
+}{
+	{func(title, city, seniority string) string { return "tcs|" + title + "|" + city + "|" + seniority }, 0.8},
+	{func(title, city, seniority string) string { return "ts|" + title + "|" + seniority }, 0.6},
+	{func(title, city, seniority string) string { return "t|" + title }, 0.4},
+}
+
+// NewSalaryEstimator groups the stated salaries of the given jobs
+func NewSalaryEstimator(jobs []models.Job) *SalaryEstimator {
+	estimator := &SalaryEstimator{groups: map[string][]models.SalaryRange{}}
+	for _, job := range jobs {
+		if job.Salary == nil {
+			continue
+		}
+		title, city, seniority := salaryKeyParts(job)
+		for _, level := range salaryEstimateLevels {
+			key := level.key(title, city, seniority)
+			estimator.groups[key] = append(estimator.groups[key], *job.Salary)
+		}
+	}
+	return estimator
+}
+
+// Estimate returns the median range of the most specific group with enough samples, nil without one.
+// Confidence grows with the number of samples up to the level's maximum at ten.
+func (e *SalaryEstimator) Estimate(job models.Job) *models.SalaryRange {
+	title, city, seniority := salaryKeyParts(job)
+	for _, level := range salaryEstimateLevels {
+		samples := e.groups[level.key(title, city, seniority)]
+		samples = majorityCurrency(samples)
+		if len(samples) < minSalarySamples {
+			continue
+		}
+		mins := make([]int, 0, len(samples))
+		maxs := make([]int, 0, len(samples))
+		for _, sample := range samples {
+			mins = append(mins, sample.Min)
+			maxs = append(maxs, sample.Max)
+		}
+		confidence := level.confidence * math.Min(1, float64(len(samples))/10)
+		return &models.SalaryRange{
+			Min:        medianInt(mins),
+			Max:        medianInt(maxs),
+			Currency:   samples[0].Currency,
+			Period:     SalaryPerYear,
+			Source:     models.SalarySourceEstimate,
+			Confidence: math.Round(confidence*100) / 100,
+			SampleSize: len(samples),
+		}
+	}
+	return nil
+}
+
+func salaryKeyParts(job models.Job) (string, string, string) {
+	city, _, _ := strings.Cut(NormalizeJobLocation(job.Location), ",")
+	seniority := job.Seniority
+	if seniority == "" {
+		seniority = JobSeniority(job.Title)
+	}
+	return strings.ToLower(strings.Join(strings.Fields(job.Title), " ")), strings.ToLower(city), seniority
+}
+
+// majorityCurrency keeps the samples in the most common currency, salaries in different currencies do not mix
+func majorityCurrency(samples []models.SalaryRange) []models.SalaryRange {
+	counts := map[string]int{}
+	best := ""
+	for _, sample := range samples {
+		counts[sample.Currency]++
+		if counts[sample.Currency] > counts[best] || (counts[sample.Currency] == counts[best] && sample.Currency < best) {
+			best = sample.Currency
+		}
+	}
+	kept := []models.SalaryRange{}
+	for _, sample := range samples {
+		if sample.Currency == best {
+			kept = append(kept, sample)
+		}
+	}
+	return kept
+}
+
+func medianInt(values []int) int {
+	sort.Ints(values)
+	middle := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[middle]
+	}
+	return (values[middle-1] + values[middle]) / 2
+}
+
+// loadSalarySamples reads the jobs with a stated salary, all of them or those with one of the titles
+func loadSalarySamples(ctx context.Context, db *mongo.Database, titles []string) ([]models.Job, error) {
+	filter := bson.M{"salary": bson.M{"$exists": true}}
+	if titles != nil {
+		filter["title"] = bson.M{"$in": titles}
+	}
+	cursor, err := db.Collection("jobs").Find(ctx, filter,
+		options.Find().SetProjection(bson.M{"title": 1, "location": 1, "seniority": 1, "salary": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var samples []models.Job
+	err = cursor.All(ctx, &samples)
+	return samples, err
+}
+
+// EstimateJobSalaries stores a salary_estimate for the given jobs that state no salary,
+// from the stated salaries of jobs with the same titles
+func EstimateJobSalaries(ctx context.Context, db *mongo.Database, jobIDs []string) error {
+	if len(jobIDs) == 0 {
+		return nil
+	}
+	cursor, err := db.Collection("jobs").Find(ctx,
+		bson.M{"job_id": bson.M{"$in": jobIDs}, "salary": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{"job_id": 1, "title": 1, "location": 1, "seniority": 1}))
+	if err != nil {
+		return err
+	}
+	var jobs []models.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	titles := []string{}
+	for _, job := range jobs {
+		titles = append(titles, job.Title)
+	}
+	samples, err := loadSalarySamples(ctx, db, uniqueStrings(titles, nil))
+	if err != nil {
+		return err
+	}
+	estimator := NewSalaryEstimator(samples)
+
+	writes := make([]mongo.WriteModel, 0, len(jobs))
+	for _, job := range jobs {
+		writes = append(writes, salaryEstimateWrite(job.JobID, estimator.Estimate(job)))
+	}
+	_, err = db.Collection("jobs").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func salaryEstimateWrite(jobID string, estimate *models.SalaryRange) mongo.WriteModel {
+	update := bson.M{"$unset": bson.M{"salary_estimate": ""}}
+	if estimate != nil {
+		update = bson.M{"$set": bson.M{"salary_estimate": estimate}}
+	}
+	return mongo.NewUpdateOneModel().SetFilter(bson.M{"job_id": jobID}).SetUpdate(update)
+}
+
+// RefreshJobSalaries re-derives seniority and parsed salaries of every job, then re-estimates the
+// jobs without a stated salary from the whole collection. Salaries sent by the scrapers are kept.
+// It returns the number of jobs with a stated salary and with an estimate.
+func RefreshJobSalaries(ctx context.Context, db *mongo.Database) (int, int, error) {
+	jobsCollection := db.Collection("jobs")
+	cursor, err := jobsCollection.Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"job_id": 1, "title": 1, "location": 1, "job_description": 1, "salary": 1}))
+	if err != nil {
+		return 0, 0, err
+	}
+	var jobs []models.Job
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return 0, 0, err
+	}
+
+	stated := 0
+	for i := range jobs {
+		jobs[i].Seniority = JobSeniority(jobs[i].Title)
+		if jobs[i].Salary == nil || jobs[i].Salary.Source != models.SalarySourceProvided {
+			jobs[i].Salary = ExtractSalary(jobs[i].JobDescription)
+		}
+		if jobs[i].Salary != nil {
+			stated++
+		}
+	}
+	estimator := NewSalaryEstimator(jobs)
+
+	estimated := 0
+	writes := make([]mongo.WriteModel, 0, len(jobs))
+	for _, job := range jobs {
+		set := bson.M{"seniority": job.Seniority}
+		unset := bson.M{}
+		if job.Salary != nil {
+			set["salary"] = job.Salary
+			unset["salary_estimate"] = ""
+		} else {
+			unset["salary"] = ""
+			if estimate := estimator.Estimate(job); estimate != nil {
+				set["salary_estimate"] = estimate
+				estimated++
+			} else {
+				unset["salary_estimate"] = ""
+			}
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"job_id": job.JobID}).
+			SetUpdate(bson.M{"$set": set, "$unset": unset}))
+	}
+	for start := 0; start < len(writes); start += 1000 {
+		end := min(start+1000, len(writes))
+		if _, err := jobsCollection.BulkWrite(ctx, writes[start:end], options.BulkWrite().SetOrdered(false)); err != nil {
+			return 0, 0, err
+		}
+	}
+	return stated, estimated, nil
+}
+
+// BackfillJobSalaries runs RefreshJobSalaries once for jobs stored before salaries were parsed,
+// so the salary filters of the feed see them. Those jobs have no seniority, which every refresh
+// sets; the refresh covers the whole collection because estimates draw on every stated salary.
+// It returns the number of jobs that had not been refreshed yet.
+func BackfillJobSalaries(ctx context.Context, db *mongo.Database) (int, error) {
+	pending, err := db.Collection("jobs").CountDocuments(ctx, bson.M{"seniority": bson.M{"$exists": false}})
+	if err != nil || pending == 0 {
+		return 0, err
+	}
+	if _, _, err := RefreshJobSalaries(ctx, db); err != nil {
+		return 0, err
+	}
+	return int(pending), nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"testing"
+)
+
+func TestExtractSalary(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		want        *models.SalaryRange
+	}{
+		{"euro range with period", "Salary: 50.000 - 70.000 EUR per year", &models.SalaryRange{Min: 50000, Max: 70000, Currency: "EUR", Period: SalaryPerYear}},
+		{"k range", "We offer €50k-70k plus equity", &models.SalaryRange{Min: 50000, Max: 70000, Currency: "EUR", Period: SalaryPerYear}},
+		{"k on the upper bound only", "Salary: €50-70k", &models.SalaryRange{Min: 50000, Max: 70000, Currency: "EUR", Period: SalaryPerYear}},
+		{"dollar range with to", "Base pay $120,000 to $150,000", &models.SalaryRange{Min: 120000, Max: 150000, Currency: "USD", Period: SalaryPerYear}},
+		{"german monthly range", "Gehalt: 4.500 bis 5.500 Euro monatlich", &models.SalaryRange{Min: 54000, Max: 66000, Currency: "EUR", Period: SalaryPerMonth}},
+		{"hourly rate with currency", "Stundenlohn 25 € pro Stunde", &models.SalaryRange{Min: 52000, Max: 52000, Currency: "EUR", Period: SalaryPerHour}},
+		{"keyword and currency without period read by size", "Salary from £3.000", &models.SalaryRange{Min: 36000, Max: 36000, Currency: "GBP", Period: SalaryPerMonth}},
+		{"currency range without keyword", "Offering 60.000 - 75.000 EUR", &models.SalaryRange{Min: 60000, Max: 75000, Currency: "EUR", Period: SalaryPerYear}},
+		{"currency with period without keyword", "Earn €4.000 per month", &models.SalaryRange{Min: 48000, Max: 48000, Currency: "EUR", Period: SalaryPerMonth}},
+		{"salary before a bonus", "Salary €60.000 p.a. plus €5k signing bonus", &models.SalaryRange{Min: 60000, Max: 60000, Currency: "EUR", Period: SalaryPerYear}},
+		{"salary after a funding round", "We raised $20M last year. Salary: $90k", &models.SalaryRange{Min: 90000, Max: 90000, Currency: "USD", Period: SalaryPerYear}},
+		{"keyword and k without currency", "Salary: 60k depending on experience", &models.SalaryRange{Min: 60000, Max: 60000, Period: SalaryPerYear}},
+		{"keyword and stated period without currency", "Compensation: 30 per hour", &models.SalaryRange{Min: 62400, Max: 62400, Period: SalaryPerHour}},
+		{"keyword and monthly without currency", "Pay: 5000 monthly", &models.SalaryRange{Min: 60000, Max: 60000, Period: SalaryPerMonth}},
+		{"years of experience before the salary", "5 years of experience. Salary: €65.000 p.a.", &models.SalaryRange{Min: 65000, Max: 65000, Currency: "EUR", Period: SalaryPerYear}},
+
+		{"years of experience after keyword", "Competitive salary. 5 years of experience required.", nil},
+		{"years in the salary sentence", "Salary: depends on 10 years of experience", nil},
+		{"days remote", "Compensation: 3 days remote per week", nil},
+		{"german years", "Gehalt nach Vereinbarung, mindestens 3 Jahre Berufserfahrung", nil},
+		{"german days", "Vergütung attraktiv, 30 Tage Urlaub", nil},
+		{"weeks", "Salary review after 6 weeks", nil},
+		{"percent", "Salary plus 10% bonus", nil},
+		{"hours per week", "Salary fair, 40 hours per week", nil},
+		{"years with plus", "Salary: 5+ years experience", nil},
+		{"bare number after keyword", "Salary: 45000", nil},
+		{"number without keyword or currency", "Team of 50000 people", nil},
+		{"implausible amount", "Funding of $50,000,000", nil},
+		{"no salary", "Great team, flexible hours", nil},
+		{"funding in millions", "We raised $20M in our Series B", nil},
+		{"millions spelled out", "Backed by $1.5 million in seed money", nil},
+		{"german millions", "Umsatz von 20 Mio. € im Jahr", nil},
+		{"lone currency amount", "Paying £3.000 for this role", nil},
+		{"bonus after amount", "Join now and get a 1000 € bonus", nil},
+		{"signing bonus with k", "There is a €5k signing bonus", nil},
+		{"learning budget with period", "Learning budget of €1.000 per year", nil},
+		{"bonus after salary keyword", "Salary is competitive, plus a bonus of €2.000 per year", nil},
+		{"funding with k", "Our funding: $500k from angels", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractSalary(tt.description)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("ExtractSalary = %+v, want nil", *got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("ExtractSalary = nil, want %+v", *tt.want)
+			}
+			tt.want.Source = models.SalarySourcePosting
+			if *got != *tt.want {
+				t.Errorf("ExtractSalary = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSalaryNumber(t *testing.T) {
+	tests := []struct {
+		value     string
+		thousands bool
+		want      float64
+	}{
+		{"50.000", false, 50000},
+		{"50,000", false, 50000},
+		{"65 000", false, 65000},
+		{"4.500,50", false, 4500.5},
+		{"52,5", true, 52500},
+		{"60", true, 60000},
+	}
+	for _, tt := range tests {
+		got, ok := parseSalaryNumber(tt.value, tt.thousands)
+		if !ok || got != tt.want {
+			t.Errorf("parseSalaryNumber(%q, %v) = %v, %v, want %v", tt.value, tt.thousands, got, ok, tt.want)
+		}
+	}
+}
+
+func TestJobSeniority(t *testing.T) {
+	tests := map[string]string{
+		"Werkstudent Softwareentwicklung": SeniorityIntern,
+		"Lead Backend Engineer":           SeniorityLead,
+		"Senior Go Developer":             SenioritySenior,
+		"Junior Data Analyst":             SeniorityJunior,
+		"Backend Developer":               SeniorityMid,
+	}
+	for title, want := range tests {
+		if got := JobSeniority(title); got != want {
+			t.Errorf("JobSeniority(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"RAAS/internal/models"
+
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const skillTaxonomyTTL = 5 * time.Minute
+
+// skillTaxonomy is an in-memory view of the "skills" collection used for normalization and autocomplete
+type skillTaxonomy struct {
+	skills   []models.Skill
+	byKey    map[string]*models.Skill // normalized label or synonym -> skill
+	loadedAt time.Time
+}
+
+var (
+	taxonomyMu    sync.RWMutex
+	taxonomyCache *skillTaxonomy
+)
+
+// SkillSuggestion is a single autocomplete result
+type SkillSuggestion struct {
+	SkillID string `json:"skill_id"`
+	Label   string `json:"label"`
+	Matched string `json:"matched"`
+}
+
+// skillKey folds a free-text skill into its lookup form: lower case, single spaced, no trailing punctuation
+func skillKey(skill string) string {
+	key := strings.ToLower(strings.Join(strings.Fields(skill), " "))
+	return strings.TrimRight(key, ".,;:")
+}
+
+// loadSkillTaxonomy returns the cached taxonomy, reloading it from MongoDB once it is stale
+func loadSkillTaxonomy(ctx context.Context, db *mongo.Database) (*skillTaxonomy, error) {
+	taxonomyMu.RLock()
+	cached := taxonomyCache
+	taxonomyMu.RUnlock()
+	if cached != nil && time.Since(cached.loadedAt) < skillTaxonomyTTL {
+		return cached, nil
+	}
+
+	cursor, err := db.Collection("skills").Find(ctx, activeSkillFilter())
+	if err != nil {
+		return nil, err
+	}
+	var skills []models.Skill
+	if err := cursor.All(ctx, &skills); err != nil {
+		return nil, err
+	}
+
+	taxonomy := &skillTaxonomy{skills: skills, byKey: map[string]*models.Skill{}, loadedAt: time.Now()}
+	for i := range taxonomy.skills {
+		skill := &taxonomy.skills[i]
+		taxonomy.byKey[skillKey(skill.Label)] = skill
+	}
+	// Synonyms never shadow a label of another skill
+	for i := range taxonomy.skills {
+		skill := &taxonomy.skills[i]
+		for _, synonym := range skill.Synonyms {
+			if _, exists := taxonomy.byKey[skillKey(synonym)]; !exists {
+				taxonomy.byKey[skillKey(synonym)] = skill
+			}
+		}
+	}
+
+	taxonomyMu.Lock()
+	taxonomyCache = taxonomy
+	taxonomyMu.Unlock()
+	return taxonomy, nil
+}
+
+// activeSkillFilter matches the skills of the taxonomy, leaving out the aliases left by merges
+func activeSkillFilter() bson.M {
+	return bson.M{"merged_into": bson.M{"$exists": false}}
+}
+
+// InvalidateSkillTaxonomy drops the cached taxonomy so the next lookup reloads it
+func InvalidateSkillTaxonomy() {
+	taxonomyMu.Lock()
+	taxonomyCache = nil
+	taxonomyMu.Unlock()
+}
+
+// NormalizeSkills maps free-text skills onto taxonomy labels ("golang" -> "Go", "k8s" -> "Kubernetes").
+// Unknown skills are kept as typed (trimmed). Duplicates after normalization are removed, order is kept.
+func NormalizeSkills(ctx context.Context, db *mongo.Database, skills []string) []string {
+	taxonomy, err := loadSkillTaxonomy(ctx, db)
+	if err != nil {
+		taxonomy = &skillTaxonomy{byKey: map[string]*models.Skill{}}
+	}
+
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, skill := range skills {
+		skill = strings.Join(strings.Fields(skill), " ")
+		if skill == "" {
+			continue
+		}
+		if match, ok := taxonomy.byKey[skillKey(skill)]; ok {
+			skill = match.Label
+		}
+		if !seen[skillKey(skill)] {
+			seen[skillKey(skill)] = true
+			normalized = append(normalized, skill)
+		}
+	}
+	return normalized
+}
+
+// NormalizeSkillList normalizes the comma-separated skills string stored on jobs
+func NormalizeSkillList(ctx context.Context, db *mongo.Database, skills string) string {
+	return strings.Join(NormalizeSkills(ctx, db, strings.Split(skills, ",")), ", ")
+}
+
+// SplitSkillList splits a comma-separated job skills string
+func SplitSkillList(skills string) []string {
+	var result []string
+	for _, skill := range strings.Split(skills, ",") {
+		if skill = strings.TrimSpace(skill); skill != "" {
+			result = append(result, skill)
+		}
+	}
+	return result
+}
+
+// AutocompleteSkills ranks taxonomy skills for a typed prefix:
+// label prefix matches first, then synonym prefix matches, then substring matches.
+func AutocompleteSkills(ctx context.Context, db *mongo.Database, query string, limit int) ([]SkillSuggestion, error) {
+	taxonomy, err := loadSkillTaxonomy(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	q := skillKey(query)
+	if q == "" {
+		return []SkillSuggestion{}, nil
+	}
+
+	type ranked struct {
+		SkillSuggestion
+		rank int
+	}
+	var matches []ranked
+	for _, skill := range taxonomy.skills {
+		best := ranked{rank: -1}
+		consider := func(text string, rank int) {
+			if best.rank == -1 || rank < best.rank {
+				best = ranked{SkillSuggestion{SkillID: skill.SkillID, Label: skill.Label, Matched: text}, rank}
+			}
+		}
+		if strings.HasPrefix(skillKey(skill.Label), q) {
+			consider(skill.Label, 0)
+		}
+		for _, synonym := range skill.Synonyms {
+			if strings.HasPrefix(skillKey(synonym), q) {
+				consider(synonym, 1)
+			} else if strings.Contains(skillKey(synonym), q) {
+				consider(synonym, 3)
+			}
+		}
+		if strings.Contains(skillKey(skill.Label), q) {
+			consider(skill.Label, 2)
+		}
+		if best.rank >= 0 {
+			matches = append(matches, best)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return strings.ToLower(matches[i].Label) < strings.ToLower(matches[j].Label)
+	})
+
+	suggestions := []SkillSuggestion{}
+	for i := 0; i < len(matches) && i < limit; i++ {
+		suggestions = append(suggestions, matches[i].SkillSuggestion)
+	}
+	return suggestions, nil
+}
+
+// MergeSkills folds the source skills into the target: their labels and synonyms become synonyms
+// of the target and the source documents become aliases of it. Extra synonyms can be attached in the same call.
+func MergeSkills(ctx context.Context, db *mongo.Database, targetID string, sourceIDs []string, synonyms []string) (*models.Skill, error) {
+	collection := db.Collection("skills")
+
+	var target models.Skill
+	targetFilter := activeSkillFilter()
+	targetFilter["skill_id"] = targetID
+	if err := collection.FindOne(ctx, targetFilter).Decode(&target); err != nil {
+		return nil, err
+	}
+
+	var sources []models.Skill
+	if len(sourceIDs) > 0 {
+		sourceFilter := activeSkillFilter()
+		sourceFilter["skill_id"] = bson.M{"$in": sourceIDs, "$ne": targetID}
+		cursor, err := collection.Find(ctx, sourceFilter)
+		if err != nil {
+			return nil, err
+		}
+		if err := cursor.All(ctx, &sources); err != nil {
+			return nil, err
+		}
+	}
+
+	merged := map[string]bool{skillKey(target.Label): true}
+	result := []string{}
+	add := func(s string) {
+		s = strings.Join(strings.Fields(s), " ")
+		if s != "" && !merged[skillKey(s)] {
+			merged[skillKey(s)] = true
+			result = append(result, s)
+		}
+	}
+	for _, s := range target.Synonyms {
+		add(s)
+	}
+	for _, source := range sources {
+		add(source.Label)
+		for _, s := range source.Synonyms {
+			add(s)
+		}
+	}
+	for _, s := range synonyms {
+		add(s)
+	}
+
+	target.Synonyms = result
+	target.UpdatedAt = time.Now()
+	if _, err := collection.UpdateOne(ctx, bson.M{"skill_id": targetID}, bson.M{"$set": bson.M{"synonyms": target.Synonyms, "updated_at": target.UpdatedAt}}); err != nil {
+		return nil, err
+	}
+
+	if len(sources) > 0 {
+		var ids []string
+		for _, source := range sources {
+			ids = append(ids, source.SkillID)
+		}
+		// The sources and the aliases that pointed at them now point at the target
+		if _, err := collection.UpdateMany(ctx,
+			bson.M{"$or": bson.A{bson.M{"skill_id": bson.M{"$in": ids}}, bson.M{"merged_into": bson.M{"$in": ids}}}},
+			bson.M{"$set": bson.M{"merged_into": targetID, "synonyms": []string{}, "updated_at": target.UpdatedAt}},
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	InvalidateSkillTaxonomy()
+	return &target, nil
+}
+
+// RenormalizeStoredSkills re-applies the taxonomy to every seeker and job, used after a merge.
+// It returns the number of seekers and jobs that changed.
+func RenormalizeStoredSkills(ctx context.Context, db *mongo.Database) (int, int, error) {
+	seekersChanged, jobsChanged := 0, 0
+
+	cursor, err := db.Collection("seekers").Find(ctx, bson.M{"professional_summary.skills.0": bson.M{"$exists": true}})
+	if err != nil {
+		return 0, 0, err
+	}
+	for cursor.Next(ctx) {
+		var seeker models.Seeker
+		if err := cursor.Decode(&seeker); err != nil {
+			continue
+		}
+		current := extractSkills(seeker.ProfessionalSummary)
+		normalized := NormalizeSkills(ctx, db, current)
+		if strings.Join(current, "\x00") == strings.Join(normalized, "\x00") {
+			continue
+		}
+		// A version bump, so a PUT /profile based on the skills read before the merge is rejected
+		if _, err := db.Collection("seekers").UpdateOne(ctx, bson.M{"auth_user_id": seeker.AuthUserID}, BumpVersion(bson.M{"$set": bson.M{"professional_summary.skills": normalized}})); err == nil {
+			seekersChanged++
+		}
+	}
+	cursor.Close(ctx)
+
+	cursor, err = db.Collection("jobs").Find(ctx, bson.M{"skills": bson.M{"$ne": ""}})
+	if err != nil {
+		return seekersChanged, 0, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var job models.Job
+		if err := cursor.Decode(&job); err != nil {
+			continue
+		}
+		normalized := NormalizeSkillList(ctx, db, job.Skills)
+		if normalized == job.Skills {
+			continue
+		}
+		if _, err := db.Collection("jobs").UpdateOne(ctx, bson.M{"job_id": job.JobID}, bson.M{"$set": bson.M{"skills": normalized}}); err == nil {
+			jobsChanged++
+		}
+	}
+	return seekersChanged, jobsChanged, nil
+}
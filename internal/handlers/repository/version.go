@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrVersionConflict is returned when the seeker changed since the client read it
+var ErrVersionConflict = errors.New("profile was modified by another request")
+
+// BumpVersion adds the version increment to a seeker update. Every write to a profile
+// section goes through it so If-Match on PUT /profile detects concurrent edits.
+func BumpVersion(update bson.M) bson.M {
+	inc, _ := update["$inc"].(bson.M)
+	if inc == nil {
+		inc = bson.M{}
+	}
+	inc["version"] = 1
+	update["$inc"] = inc
+	return update
+}
+
+// VersionFilter matches the seeker only at the given version. Seekers created before
+// versioning have no field yet and count as version 0.
+func VersionFilter(userID string, version int64) bson.M {
+	if version == 0 {
+		return bson.M{"auth_user_id": userID, "$or": []bson.M{{"version": 0}, {"version": bson.M{"$exists": false}}}}
+	}
+	return bson.M{"auth_user_id": userID, "version": version}
+}
+
+// ETag renders a seeker version as a strong entity tag
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseIfMatch reads the version from an If-Match header (`"3"`, `W/"3"` or `3`)
+func ParseIfMatch(header string) (int64, bool) {
+	value := strings.TrimPrefix(strings.TrimSpace(header), "W/")
+	version, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+	return version, err == nil && version >= 0
+}
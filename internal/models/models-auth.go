@@ -26,6 +26,14 @@ type AuthUser struct {
 	PasswordLastUpdated  *time.Time `json:"password_last_updated,omitempty" bson:"password_last_updated,omitempty"`
 	TwoFactorEnabled     bool       `json:"two_factor_enabled" bson:"two_factor_enabled"`
 	TwoFactorSecret      *string    `json:"two_factor_secret,omitempty" bson:"two_factor_secret,omitempty"`
+
+	// Set while a requested account deletion is in its grace period; the purge runs at DeletionScheduledFor
+	DeletionRequestedAt  *time.Time `json:"deletion_requested_at,omitempty" bson:"deletion_requested_at,omitempty"`
+	DeletionScheduledFor *time.Time `json:"deletion_scheduled_for,omitempty" bson:"deletion_scheduled_for,omitempty"`
+
+	// Accounts without password or two-factor authentication confirm a deletion with an emailed code, stored hashed
+	DeletionConfirmationHash   string     `json:"-" bson:"deletion_confirmation_hash,omitempty"`
+	DeletionConfirmationExpiry *time.Time `json:"-" bson:"deletion_confirmation_expiry,omitempty"`
 }
 
 
@@ -42,10 +50,15 @@ func CreateAuthUserIndexes(collection *mongo.Collection) error {
 		Keys:    bson.D{{Key: "email", Value: 1}, {Key: "phone", Value: 1}},
 		Options: options.Index().SetUnique(true),
 	}
+	indexModelDeletion := mongo.IndexModel{
+		Keys:    bson.D{{Key: "deletion_scheduled_for", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}
 	_, err := collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
 		indexModelEmail,
 		indexModelPhone,
 		indexModelCompound,
+		indexModelDeletion,
 	})
 	return err
 }
@@ -71,6 +84,20 @@ type Seeker struct {
 	PrimaryTitle                string             `json:"primary_title" bson:"primary_title"`
 	SecondaryTitle              *string            `json:"secondary_title,omitempty" bson:"secondary_title,omitempty"`
 	TertiaryTitle               *string            `json:"tertiary_title,omitempty" bson:"tertiary_title,omitempty"`
+
+	ProfileCompletion           int                `json:"profile_completion" bson:"profile_completion"`
+	JobTitlesUpdatedAt          *time.Time         `json:"job_titles_updated_at,omitempty" bson:"job_titles_updated_at,omitempty"`
+
+	Personas                    []Persona          `json:"personas,omitempty" bson:"personas,omitempty"`
+	ProfilePhoto                *ProfilePhoto      `json:"profile_photo,omitempty" bson:"profile_photo,omitempty"`
+	JobPreferences              *JobPreferences    `json:"job_preferences,omitempty" bson:"job_preferences,omitempty"`
+	ProfileVisibility           *ProfileVisibility `json:"profile_visibility,omitempty" bson:"profile_visibility,omitempty"`
+
+	Projects                    []Project          `json:"projects,omitempty" bson:"projects,omitempty"`
+	Tools                       []string           `json:"tools,omitempty" bson:"tools,omitempty"`
+
+	// Version is bumped by every profile write, PUT /profile uses it for optimistic concurrency
+	Version                     int64              `json:"version" bson:"version"`
 }
 
 func CreateSeekerIndexes(collection *mongo.Collection) error {
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UserDataCollections hold the documents of a user, keyed by auth_user_id.
+// The data export collects from them and the account purge deletes from them.
+// Shared collections such as jobs do not belong here.
+var UserDataCollections = []string{
+	"seekers",
+	"user_entry_timelines",
+	"cover_letters",
+	"cv",
+	"selected_job_applications",
+	"admins",
+	"match_scores",
+	"auth_users",
+	"saved_jobs",
+	"job_title_history",
+	"profile_shares",
+	"hidden_jobs",
+	"job_views",
+	"data_exports",
+}
+
+const (
+	DataExportPending = "pending"
+	DataExportRunning = "running"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+	DataExportExpired = "expired" // the link expired and the archive was deleted
+)
+
+// DataExport is a requested archive of everything stored about a user.
+// The worker picks up pending exports, uploads the ZIP and emails a time-limited link.
+type DataExport struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	AuthUserID  string             `json:"-" bson:"auth_user_id"`
+	Status      string             `json:"status" bson:"status"`
+	RequestedAt time.Time          `json:"requested_at" bson:"requested_at"`
+	StartedAt   *time.Time         `json:"started_at,omitempty" bson:"started_at,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+	ExpiresAt   *time.Time         `json:"expires_at,omitempty" bson:"expires_at,omitempty"` // When the download link stops working
+	BlobURL     string             `json:"-" bson:"blob_url,omitempty"`
+	SizeBytes   int64              `json:"size_bytes,omitempty" bson:"size_bytes,omitempty"`
+	Error       string             `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+func CreateDataExportIndexes(collection *mongo.Collection) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "auth_user_id", Value: 1}, {Key: "requested_at", Value: -1}},
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "requested_at", Value: 1}},
+			Options: options.Index().SetName("status_requested_at"),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}},
+		},
+	}
+	_, err := collection.Indexes().CreateMany(context.Background(), indexModels)
+	return err
+}
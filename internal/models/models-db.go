@@ -43,9 +43,15 @@ func InitDB(cfg *config.Config) (*mongo.Client, *mongo.Database) {
 	// Call the CreateAllIndexes function to create the necessary indexes for all models
 	CreateAllIndexes()
 
+	// Seed the skills taxonomy from the bundled file (only missing skills are inserted)
+	SeedSkills(MongoDB.Collection("skills"), cfg.Project.SkillsTaxonomyFile)
+
+	// Seed the default onboarding steps when no rules have been stored yet
+	SeedOnboardingSteps(MongoDB.Collection("onboarding_steps"))
+
 	// Now, select the "jobs" collection
 	// collection := MongoDB.Collection("jobs") // Replace with your actual collection name
-	// SeedJobs(collection)
+	// SeedJobs(collection, nil)
 
 
 	// Return the client and MongoDB database instances
@@ -144,6 +150,38 @@ func CreateAllIndexes() {
 			CollectionName:    "jobs", // Add Job index creation
 			CreateIndexesFunc: CreateJobIndexes, // Add Job index creation (hash for selected count and unique for jobId/jobLink)
 		},
+		{
+			CollectionName:    "skills",
+			CreateIndexesFunc: CreateSkillIndexes,
+		},
+		{
+			CollectionName:    "onboarding_steps",
+			CreateIndexesFunc: CreateOnboardingStepIndexes,
+		},
+		{
+			CollectionName:    "job_title_history",
+			CreateIndexesFunc: CreateJobTitleHistoryIndexes,
+		},
+		{
+			CollectionName:    "profile_shares",
+			CreateIndexesFunc: CreateProfileShareIndexes,
+		},
+		{
+			CollectionName:    "data_exports",
+			CreateIndexesFunc: CreateDataExportIndexes,
+		},
+		{
+			CollectionName:    "ingest_requests",
+			CreateIndexesFunc: CreateIngestRequestIndexes,
+		},
+		{
+			CollectionName:    "hidden_jobs",
+			CreateIndexesFunc: CreateHiddenJobIndexes,
+		},
+		{
+			CollectionName:    "job_views",
+			CreateIndexesFunc: CreateJobViewIndexes,
+		},
 	}
 	
 	// Iterate over each task and execute the index creation
@@ -155,4 +193,4 @@ func CreateAllIndexes() {
 			// log.Printf("Indexes for %s created successfully!", task.CollectionName)
 		}
 	}
-}
\ No newline at end of file
+}
\ No newline at end of file
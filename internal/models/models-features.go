@@ -34,6 +34,12 @@ type UserEntryTimeline struct {
 	PreferredJobTitlesCompleted    bool               `bson:"preferred_job_titles_completed" json:"preferred_job_titles_completed"`
 	PreferredJobTitlesRequired     bool               `bson:"preferred_job_titles_required" json:"preferred_job_titles_required"`
 
+	JobPreferencesCompleted        bool               `bson:"job_preferences_completed" json:"job_preferences_completed"`
+	JobPreferencesRequired         bool               `bson:"job_preferences_required" json:"job_preferences_required"`
+
+	ProjectsCompleted              bool               `bson:"projects_completed" json:"projects_completed"`
+	ProjectsRequired               bool               `bson:"projects_required" json:"projects_required"`
+
 	Completed                      bool               `bson:"completed" json:"completed"`
 
 	CreatedAt                      time.Time          `bson:"created_at" json:"created_at"`
@@ -50,9 +56,22 @@ func CreateUserEntryTimelineIndexes(collection *mongo.Collection) error {
 }
 
 
+// Where a salary comes from
+const (
+	SalarySourceProvided = "provided" // Sent by the scraper
+	SalarySourcePosting  = "posting"  // Parsed from the job description
+	SalarySourceEstimate = "estimate" // Estimated from similar jobs
+)
+
+// SalaryRange is a yearly gross salary. Period keeps the unit the posting used (hour, month or year).
 type SalaryRange struct {
-	Min int `bson:"min" json:"min"`
-	Max int `bson:"max" json:"max"`
+	Min        int     `bson:"min" json:"min"`
+	Max        int     `bson:"max" json:"max"`
+	Currency   string  `bson:"currency,omitempty" json:"currency,omitempty"`
+	Period     string  `bson:"period,omitempty" json:"period,omitempty"`
+	Source     string  `bson:"source,omitempty" json:"source,omitempty"`
+	Confidence float64 `bson:"confidence,omitempty" json:"confidence,omitempty"`   // 0-1, estimates only
+	SampleSize int     `bson:"sample_size,omitempty" json:"sample_size,omitempty"` // Jobs the estimate is based on
 }
 
 type SelectedJobApplication struct {
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// HiddenJob is a job the user does not want to see in the feed again
+type HiddenJob struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuthUserID string             `bson:"auth_user_id" json:"auth_user_id"`
+	JobID      string             `bson:"job_id" json:"job_id"`
+	HiddenAt   time.Time          `bson:"hidden_at" json:"hidden_at"`
+}
+
+func CreateHiddenJobIndexes(collection *mongo.Collection) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "auth_user_id", Value: 1}, {Key: "job_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+	_, err := collection.Indexes().CreateMany(context.Background(), indexModels)
+	return err
+}
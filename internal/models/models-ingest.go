@@ -0,0 +1,49 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	IngestRequestProcessing = "processing"
+	IngestRequestCompleted  = "completed"
+)
+
+// IngestRequestTTL is how long an idempotency key is remembered
+const IngestRequestTTL = 24 * time.Hour
+
+// IngestRequest remembers an ingestion batch by its Idempotency-Key, so a scraper retrying
+// after a timeout gets the original result instead of a second write.
+type IngestRequest struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Client      string             `bson:"client"`
+	Key         string             `bson:"key"`
+	RequestHash string             `bson:"request_hash"` // SHA-256 of the body; a reused key with another body is rejected
+	Status      string             `bson:"status"`
+	StatusCode  int                `bson:"status_code,omitempty"`
+	Response    bson.Raw           `bson:"response,omitempty"`
+	CreatedAt   time.Time          `bson:"created_at"`
+	StartedAt   time.Time          `bson:"started_at"` // When the request holding the key started; a stale one is taken over
+	CompletedAt *time.Time         `bson:"completed_at,omitempty"`
+}
+
+func CreateIngestRequestIndexes(collection *mongo.Collection) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "client", Value: 1}, {Key: "key", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(IngestRequestTTL.Seconds())),
+		},
+	}
+	_, err := collection.Indexes().CreateMany(context.Background(), indexModels)
+	return err
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Work modes a seeker can accept
+const (
+	WorkModeRemote = "remote"
+	WorkModeHybrid = "hybrid"
+	WorkModeOnsite = "onsite"
+)
+
+// Employment types a seeker can accept
+const (
+	EmploymentFullTime   = "full_time"
+	EmploymentPartTime   = "part_time"
+	EmploymentContract   = "contract"
+	EmploymentTemporary  = "temporary"
+	EmploymentInternship = "internship"
+	EmploymentFreelance  = "freelance"
+)
+
+// PreferredLocation is a city the seeker wants to work in, plus how far they would commute
+type PreferredLocation struct {
+	City     string `json:"city" bson:"city"`
+	RadiusKm int    `json:"radius_km" bson:"radius_km"`
+}
+
+// JobPreferences are the search preferences that narrow the job feed beyond the preferred titles.
+// Empty lists place no restriction.
+type JobPreferences struct {
+	Locations         []PreferredLocation `json:"locations" bson:"locations"`
+	WorkModes         []string            `json:"work_modes" bson:"work_modes"`
+	MinSalary         int                 `json:"min_salary" bson:"min_salary"` // Yearly gross, 0 = no minimum
+	EmploymentTypes   []string            `json:"employment_types" bson:"employment_types"`
+	Languages         []string            `json:"languages" bson:"languages"` // ISO 639-1 codes
+	Industries        []string            `json:"industries" bson:"industries"`
+	ExcludedCompanies []string            `json:"excluded_companies" bson:"excluded_companies"`
+	UpdatedAt         time.Time           `json:"updated_at" bson:"updated_at"`
+}
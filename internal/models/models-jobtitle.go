@@ -0,0 +1,36 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JobTitleSet is the primary/secondary/tertiary title triple of a seeker
+type JobTitleSet struct {
+	PrimaryTitle   string  `json:"primary_title" bson:"primary_title"`
+	SecondaryTitle *string `json:"secondary_title,omitempty" bson:"secondary_title,omitempty"`
+	TertiaryTitle  *string `json:"tertiary_title,omitempty" bson:"tertiary_title,omitempty"`
+}
+
+// JobTitleChange is one entry of the preferred job titles history
+type JobTitleChange struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	AuthUserID string             `json:"auth_user_id" bson:"auth_user_id"`
+	Previous   JobTitleSet        `json:"previous" bson:"previous"`
+	Current    JobTitleSet        `json:"current" bson:"current"`
+	ChangedAt  time.Time          `json:"changed_at" bson:"changed_at"`
+}
+
+func CreateJobTitleHistoryIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "auth_user_id", Value: 1}, {Key: "changed_at", Value: -1}},
+		Options: options.Index().SetUnique(false),
+	}
+	_, err := collection.Indexes().CreateOne(context.Background(), indexModel)
+	return err
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// JobViewTTL is how long a view is kept. A view only has to outlive its day to keep reloads from
+// counting again, the total stays in the job's view_count.
+const JobViewTTL = 48 * time.Hour
+
+// JobView records that a user opened a job's detail page. Views count once per user, job and day,
+// so reloading the page does not push a job up the popular ranking. Views expire after JobViewTTL.
+type JobView struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AuthUserID string             `bson:"auth_user_id" json:"auth_user_id"`
+	JobID      string             `bson:"job_id" json:"job_id"`
+	Day        string             `bson:"day" json:"day"` // YYYY-MM-DD in UTC
+	ViewedAt   time.Time          `bson:"viewed_at" json:"viewed_at"`
+}
+
+func CreateJobViewIndexes(collection *mongo.Collection) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "auth_user_id", Value: 1}, {Key: "job_id", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "job_id", Value: 1}, {Key: "viewed_at", Value: -1}},
+		},
+		{
+			Keys:    bson.D{{Key: "viewed_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(JobViewTTL.Seconds())),
+		},
+	}
+	_, err := collection.Indexes().CreateMany(context.Background(), indexModels)
+	return err
+}
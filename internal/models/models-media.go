@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// ProfilePhoto points to the re-encoded profile photo and its square thumbnails (keyed by size in px)
+type ProfilePhoto struct {
+	URL        string            `json:"url" bson:"url"`
+	Thumbnails map[string]string `json:"thumbnails" bson:"thumbnails"`
+	UpdatedAt  time.Time         `json:"updated_at" bson:"updated_at"`
+}
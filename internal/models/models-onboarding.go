@@ -0,0 +1,107 @@
+package models
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Operators understood by the onboarding predicate evaluator
+const (
+	PredicateExists   = "exists"    // field is present and not null
+	PredicateNotEmpty = "not_empty" // non-blank string, non-empty array or document
+	PredicateMinItems = "min_items" // array holds at least Value items
+	PredicateEquals   = "equals"    // field equals Value
+)
+
+// OnboardingPredicate is evaluated against the seeker document. Field is a dotted path
+// such as "personal_info.first_name" or "work_experiences".
+type OnboardingPredicate struct {
+	Field string      `json:"field" bson:"field" binding:"required"`
+	Op    string      `json:"op" bson:"op" binding:"required"`
+	Value interface{} `json:"value,omitempty" bson:"value,omitempty"`
+}
+
+// OnboardingStep is one step of the user entry timeline. A step is required when Required is set
+// or the seeker's subscription tier is listed in RequiredTiers. When Predicates are defined the
+// step is complete once all of them hold, otherwise the "<key>_completed" timeline flag is used.
+type OnboardingStep struct {
+	Key           string                `json:"key" bson:"key" binding:"required"`
+	Order         int                   `json:"order" bson:"order"`
+	Required      bool                  `json:"required" bson:"required"`
+	RequiredTiers []string              `json:"required_tiers,omitempty" bson:"required_tiers,omitempty"`
+	Predicates    []OnboardingPredicate `json:"predicates,omitempty" bson:"predicates,omitempty"`
+	UpdatedAt     time.Time             `json:"updated_at" bson:"updated_at"`
+}
+
+// DefaultOnboardingSteps mirrors the flow that used to be hard-coded in CreateSeeker and GetNextEntryStep
+func DefaultOnboardingSteps() []OnboardingStep {
+	return []OnboardingStep{
+		{Key: "personal_infos", Order: 1, Required: true, Predicates: []OnboardingPredicate{
+			{Field: "personal_info.first_name", Op: PredicateNotEmpty},
+			{Field: "personal_info.date_of_birth", Op: PredicateNotEmpty},
+		}},
+		{Key: "professional_summaries", Order: 2, Required: true, Predicates: []OnboardingPredicate{
+			{Field: "professional_summary.about", Op: PredicateNotEmpty},
+			{Field: "professional_summary.skills", Op: PredicateMinItems, Value: 1},
+		}},
+		{Key: "work_experiences", Order: 3, Predicates: []OnboardingPredicate{
+			{Field: "work_experiences", Op: PredicateMinItems, Value: 1},
+		}},
+		{Key: "educations", Order: 4, Predicates: []OnboardingPredicate{
+			{Field: "education", Op: PredicateMinItems, Value: 1},
+		}},
+		{Key: "certificates", Order: 5, Predicates: []OnboardingPredicate{
+			{Field: "certificates", Op: PredicateMinItems, Value: 1},
+		}},
+		{Key: "languages", Order: 6, Predicates: []OnboardingPredicate{
+			{Field: "languages", Op: PredicateMinItems, Value: 1},
+		}},
+		{Key: "preferred_job_titles", Order: 7, Required: true, Predicates: []OnboardingPredicate{
+			{Field: "primary_title", Op: PredicateNotEmpty},
+		}},
+		{Key: "job_preferences", Order: 8, Predicates: []OnboardingPredicate{
+			{Field: "job_preferences", Op: PredicateExists},
+		}},
+		{Key: "projects", Order: 9, Predicates: []OnboardingPredicate{
+			{Field: "projects", Op: PredicateMinItems, Value: 1},
+		}},
+	}
+}
+
+func CreateOnboardingStepIndexes(collection *mongo.Collection) error {
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	_, err := collection.Indexes().CreateOne(context.Background(), indexModel)
+	return err
+}
+
+// SeedOnboardingSteps stores the default steps when the collection is still empty.
+// Defaults added in later releases reach existing deployments through a startup migration.
+func SeedOnboardingSteps(collection *mongo.Collection) {
+	count, err := collection.CountDocuments(context.Background(), bson.M{})
+	if err != nil {
+		log.Printf("⚠️ Error counting onboarding steps: %v", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	var docs []interface{}
+	for _, step := range DefaultOnboardingSteps() {
+		step.UpdatedAt = time.Now()
+		docs = append(docs, step)
+	}
+	if _, err := collection.InsertMany(context.Background(), docs); err != nil {
+		log.Printf("⚠️ Error seeding onboarding steps: %v", err)
+		return
+	}
+	log.Printf("✅ Seeded %d default onboarding steps", len(docs))
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Persona is a named target profile layered on the base Seeker document.
+// Its titles replace the seeker's titles; Summary, Skills and WorkExperienceIDs
+// override the base profile only when set. Work experiences are referenced by their "id".
+type Persona struct {
+	PersonaID         string    `json:"persona_id" bson:"persona_id"`
+	Name              string    `json:"name" bson:"name"`
+	PrimaryTitle      string    `json:"primary_title" bson:"primary_title"`
+	SecondaryTitle    *string   `json:"secondary_title,omitempty" bson:"secondary_title,omitempty"`
+	TertiaryTitle     *string   `json:"tertiary_title,omitempty" bson:"tertiary_title,omitempty"`
+	Summary           *string   `json:"summary,omitempty" bson:"summary,omitempty"`
+	Skills            []string  `json:"skills,omitempty" bson:"skills,omitempty"`
+	WorkExperienceIDs []string  `json:"work_experience_ids,omitempty" bson:"work_experience_ids,omitempty"`
+	CreatedAt         time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" bson:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Project is a portfolio entry of the seeker. Dates are optional, EndDate is nil for ongoing projects.
+type Project struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Title       string             `json:"title" bson:"title"`
+	Role        string             `json:"role,omitempty" bson:"role,omitempty"`
+	Description string             `json:"description,omitempty" bson:"description,omitempty"`
+	TechStack   []string           `json:"tech_stack,omitempty" bson:"tech_stack,omitempty"`
+	Links       []string           `json:"links,omitempty" bson:"links,omitempty"`
+	StartDate   *time.Time         `json:"start_date,omitempty" bson:"start_date,omitempty"`
+	EndDate     *time.Time         `json:"end_date,omitempty" bson:"end_date,omitempty"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+}
@@ -10,7 +10,8 @@ import (
 )
 
 
-func SeedJobs(collection *mongo.Collection) {
+// SeedJobs upserts the sample jobs. normalizeSkills, when set, maps the skills list onto the skills taxonomy.
+func SeedJobs(collection *mongo.Collection, normalizeSkills func(string) string) {
     jobs := []Job{
         {JobID: "L001", Title: "Software Engineer", Company: "LinkedIn", Location: "Berlin", PostedDate: "2024-04-01", Link: "https://linkedin.com/jobs/1", Processed: true, Source: "LinkedIn", JobDescription: "We are looking for a skilled Software Engineer to build scalable systems.", JobType: "Full-time", Skills: "Go, REST, Microservices, Docker", JobLink: "https://apply.linkedin.com/job/1"},
         {JobID: "L002", Title: "DevOps Engineer", Company: "Google", Location: "Munich", PostedDate: "2024-04-02", Link: "https://linkedin.com/jobs/2", Processed: true, Source: "LinkedIn", JobDescription: "Join our DevOps team to manage CI/CD pipelines and cloud infrastructure.", JobType: "Full-time", Skills: "CI/CD, Jenkins, AWS, Docker, Kubernetes", JobLink: "https://apply.linkedin.com/job/2"},
@@ -44,6 +45,10 @@ func SeedJobs(collection *mongo.Collection) {
             continue
         }
 
+        if normalizeSkills != nil {
+            job.Skills = normalizeSkills(job.Skills)
+        }
+
         // Create the BSON document for update
         jobBson := bson.M{
             "job_id":         job.JobID,
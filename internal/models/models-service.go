@@ -3,6 +3,7 @@ package models
 import (
 
 	"context"
+	"time"
 	
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -13,7 +14,9 @@ import (
 
 type Job struct {
     JobID          string `bson:"job_id" json:"job_id"`
+    SeqID          uint   `bson:"seq_id,omitempty" json:"seq_id,omitempty"` // Stable numeric id, assigned once from the job_id counter
     Title          string `bson:"title" json:"title"`
+    TitleKey       string `bson:"title_key,omitempty" json:"-"` // Lower-case, single-spaced title for spelling lookups
     Company        string `bson:"company" json:"company"`
     Location       string `bson:"location" json:"location"`
     PostedDate     string `bson:"posted_date" json:"posted_date"`
@@ -25,6 +28,59 @@ type Job struct {
     Skills         string `bson:"skills" json:"skills"`
     JobLink        string `bson:"job_link" json:"job_link"`
     SelectedCount  int    `bson:"selected_count" json:"selected_count"` // Added selectedCount field
+    ViewCount      int    `bson:"view_count" json:"view_count"`         // Detail page views, one per user and day
+
+    RequiredLanguages []LanguageRequirement `bson:"required_languages,omitempty" json:"required_languages,omitempty"`
+    Industry          string                `bson:"industry,omitempty" json:"industry,omitempty"`
+    WorkMode          string                `bson:"work_mode,omitempty" json:"work_mode,omitempty"` // remote, hybrid or onsite
+
+    // Set by the ingestion API: first and latest batch that contained the job
+    FirstSeenAt       *time.Time            `bson:"first_seen_at,omitempty" json:"first_seen_at,omitempty"`
+    LastSeenAt        *time.Time            `bson:"last_seen_at,omitempty" json:"last_seen_at,omitempty"`
+
+    // De-duplication: the same opening posted on several boards shares a fingerprint.
+    // Duplicates point to their canonical job, which lists the links of the whole group.
+    Fingerprint       string                `bson:"fingerprint,omitempty" json:"fingerprint,omitempty"`
+    DescriptionHash   int64                 `bson:"description_hash,omitempty" json:"-"` // SimHash of the description shingles
+    CanonicalJobID    string                `bson:"canonical_job_id,omitempty" json:"canonical_job_id,omitempty"`
+    SourceLinks       []SourceLink          `bson:"source_links,omitempty" json:"source_links,omitempty"`
+
+    // Salary is stated by the scraper or parsed from the description, SalaryEstimate is derived
+    // from similar jobs when it is not. Filters only look at the stated salary.
+    Seniority         string                `bson:"seniority,omitempty" json:"seniority,omitempty"` // intern, junior, mid, senior or lead
+    Salary            *SalaryRange          `bson:"salary,omitempty" json:"salary,omitempty"`
+    SalaryEstimate    *SalaryRange          `bson:"salary_estimate,omitempty" json:"salary_estimate,omitempty"`
+
+    // Lifecycle: only active jobs are listed. Jobs stored before statuses existed have none and count as active.
+    Status            string                `bson:"status,omitempty" json:"status,omitempty"` // One of the JobStatus* values
+    ExpiresAt         *time.Time            `bson:"expires_at,omitempty" json:"expires_at,omitempty"`
+    ClosedAt          *time.Time            `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+    LinkCheckedAt     *time.Time            `bson:"link_checked_at,omitempty" json:"link_checked_at,omitempty"`
+    LinkFailures      int                   `bson:"link_failures,omitempty" json:"link_failures,omitempty"` // Consecutive checks that got no answer
+}
+
+// Job statuses
+const (
+	JobStatusActive  = "active"
+	JobStatusExpired = "expired" // Older than the expiry rules allow
+	JobStatusFilled  = "filled"  // The posting says it no longer takes applications
+	JobStatusRemoved = "removed" // The posting is gone
+)
+
+// SourceLink is one posting of a canonical job
+type SourceLink struct {
+    JobID   string `bson:"job_id" json:"job_id"`
+    Source  string `bson:"source" json:"source"`
+    Link    string `bson:"link,omitempty" json:"link,omitempty"`
+    JobLink string `bson:"job_link,omitempty" json:"job_link,omitempty"`
+}
+
+// LanguageRequirement is a language at a minimum CEFR level. Rank (A1 = 1 ... C2 = 6, native = 7)
+// is stored next to the level so it can be compared in queries.
+type LanguageRequirement struct {
+    Code  string `bson:"code" json:"code"`
+    Level string `bson:"level" json:"level"`
+    Rank  int    `bson:"rank" json:"rank"`
 }
 
 func CreateJobIndexes(collection *mongo.Collection) error {
@@ -52,16 +108,73 @@ func CreateJobIndexes(collection *mongo.Collection) error {
 		Options: options.Index().SetUnique(false),       // Not unique
 	}
 
+	// Title spellings are resolved by their lookup key
+	titleKeyIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "title_key", Value: 1}, {Key: "title", Value: 1}},
+	}
+
 	// Index for posted_date (useful for filtering jobs by date)
 	postedDateIndex := mongo.IndexModel{
 		Keys:    bson.D{{Key: "posted_date", Value: 1}}, // Index on posted_date, ascending
 		Options: options.Index().SetUnique(false),      // Not unique
 	}
 
+	// Indexes for grouping duplicates by fingerprint and finding the members of a group
+	fingerprintIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "fingerprint", Value: 1}},
+	}
+	canonicalJobIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "canonical_job_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}
+	sourceLinksIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "source_links.job_id", Value: 1}},
+		Options: options.Index().SetSparse(true),
+	}
+
+	// Stable numeric ids are unique, jobs from before they existed have none yet
+	seqIdIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "seq_id", Value: 1}},
+		Options: options.Index().SetUnique(true).SetSparse(true),
+	}
+
+	// Compound indexes for the feed filters, each with the sort the feed offers
+	feedIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "canonical_job_id", Value: 1}, {Key: "posted_date", Value: -1}}},
+		{Keys: bson.D{{Key: "canonical_job_id", Value: 1}, {Key: "selected_count", Value: -1}, {Key: "view_count", Value: -1}}},
+		{Keys: bson.D{{Key: "job_type", Value: 1}, {Key: "posted_date", Value: -1}}},
+		{Keys: bson.D{{Key: "company", Value: 1}, {Key: "posted_date", Value: -1}}},
+		{Keys: bson.D{{Key: "work_mode", Value: 1}, {Key: "posted_date", Value: -1}}},
+		{Keys: bson.D{{Key: "required_languages.code", Value: 1}, {Key: "posted_date", Value: -1}}},
+	}
+
+	// Text index for the job search, a title hit ranks above the same word in the description
+	jobTextIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: "text"},
+			{Key: "skills", Value: "text"},
+			{Key: "company", Value: "text"},
+			{Key: "job_description", Value: "text"},
+		},
+		Options: options.Index().SetName("job_text").SetWeights(bson.D{
+			{Key: "title", Value: 10},
+			{Key: "skills", Value: 5},
+			{Key: "company", Value: 3},
+			{Key: "job_description", Value: 1},
+		}),
+	}
+
+	// Indexes for the lifecycle worker: jobs due to expire and links due for a check
+	lifecycleIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "link_checked_at", Value: 1}}},
+	}
+
 	// Create indexes
-	_, err := collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
-		jobIdIndex, jobTypeIndex, selectedCountIndex, jobTitleIndex, postedDateIndex,
-	})
+	_, err := collection.Indexes().CreateMany(context.Background(), append(append([]mongo.IndexModel{
+		jobIdIndex, jobTypeIndex, selectedCountIndex, jobTitleIndex, titleKeyIndex, postedDateIndex,
+		fingerprintIndex, canonicalJobIndex, sourceLinksIndex, jobTextIndex, seqIdIndex,
+	}, feedIndexes...), lifecycleIndexes...))
 	return err
 }
 
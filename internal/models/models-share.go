@@ -0,0 +1,70 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProfileVisibility controls which sections the public profile shows.
+// Contact details are hidden unless the seeker turns them on.
+type ProfileVisibility struct {
+	Photo          bool `json:"photo" bson:"photo"`
+	Summary        bool `json:"summary" bson:"summary"`
+	Skills         bool `json:"skills" bson:"skills"`
+	WorkExperience bool `json:"work_experience" bson:"work_experience"`
+	Education      bool `json:"education" bson:"education"`
+	Certificates   bool `json:"certificates" bson:"certificates"`
+	Languages      bool `json:"languages" bson:"languages"`
+	Contact        bool `json:"contact" bson:"contact"`
+}
+
+// DefaultProfileVisibility applies until the seeker saves their own settings
+func DefaultProfileVisibility() ProfileVisibility {
+	return ProfileVisibility{
+		Photo:          true,
+		Summary:        true,
+		Skills:         true,
+		WorkExperience: true,
+		Education:      true,
+		Certificates:   true,
+		Languages:      true,
+		Contact:        false,
+	}
+}
+
+// ProfileShare is a revocable, expiring link to the seeker's public profile at /p/:slug
+type ProfileShare struct {
+	ID           primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	AuthUserID   string             `json:"-" bson:"auth_user_id"`
+	Slug         string             `json:"slug" bson:"slug"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	ExpiresAt    time.Time          `json:"expires_at" bson:"expires_at"`
+	RevokedAt    *time.Time         `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+	Views        int                `json:"views" bson:"views"`
+	LastViewedAt *time.Time         `json:"last_viewed_at,omitempty" bson:"last_viewed_at,omitempty"`
+}
+
+// Active reports whether the link still opens the profile
+func (s ProfileShare) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+func CreateProfileShareIndexes(collection *mongo.Collection) error {
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "auth_user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetUnique(false),
+		},
+	}
+	_, err := collection.Indexes().CreateMany(context.Background(), indexModels)
+	return err
+}
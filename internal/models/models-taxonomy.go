@@ -0,0 +1,85 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Skill is one concept of the skills taxonomy. Free-text skills from seekers and jobs
+// are normalized to Label when they match the label or one of the synonyms.
+// A skill merged into another is kept as an alias pointing at it, so that seeding does not bring it back.
+type Skill struct {
+	SkillID    string    `json:"skill_id" bson:"skill_id"`
+	Label      string    `json:"label" bson:"label"`
+	Synonyms   []string  `json:"synonyms" bson:"synonyms"`
+	Category   string    `json:"category,omitempty" bson:"category,omitempty"`
+	MergedInto string    `json:"merged_into,omitempty" bson:"merged_into,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+func CreateSkillIndexes(collection *mongo.Collection) error {
+	skillIDIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "skill_id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+
+	labelIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "label", Value: 1}},
+		Options: options.Index().SetUnique(false),
+	}
+
+	_, err := collection.Indexes().CreateMany(context.Background(), []mongo.IndexModel{skillIDIndex, labelIndex})
+	return err
+}
+
+// SeedSkills inserts the skills of the bundled taxonomy file that are not in the collection yet.
+// Merged skills stay in the collection as aliases, so they are not inserted again.
+// Existing documents are left alone so that synonym merges done by admins survive restarts.
+func SeedSkills(collection *mongo.Collection, path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("⚠️ Skills taxonomy file not loaded (%s): %v", path, err)
+		return
+	}
+
+	var skills []Skill
+	if err := json.Unmarshal(data, &skills); err != nil {
+		log.Printf("⚠️ Invalid skills taxonomy file %s: %v", path, err)
+		return
+	}
+
+	var writes []mongo.WriteModel
+	for _, skill := range skills {
+		if skill.SkillID == "" || skill.Label == "" {
+			continue
+		}
+		if skill.Synonyms == nil {
+			skill.Synonyms = []string{}
+		}
+		skill.UpdatedAt = time.Now()
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"skill_id": skill.SkillID}).
+			SetUpdate(bson.M{"$setOnInsert": skill}).
+			SetUpsert(true))
+	}
+
+	if len(writes) == 0 {
+		return
+	}
+
+	result, err := collection.BulkWrite(context.Background(), writes)
+	if err != nil {
+		log.Printf("⚠️ Error seeding skills taxonomy: %v", err)
+		return
+	}
+	if result.UpsertedCount > 0 {
+		log.Printf("✅ Seeded %d skills from %s", result.UpsertedCount, path)
+	}
+}
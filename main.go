@@ -4,7 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"RAAS/core/config"
 	"RAAS/app/routes"
-	// "RAAS/workers"
+	"RAAS/app/workers"
 	"RAAS/internal/models" 
 
 
@@ -30,7 +30,15 @@ func main() {
 	}
 
 	// Initialize MongoDB client and database using models.InitDB
-	client, _ := models.InitDB(config.Cfg) // Get both client and database
+	client, db := models.InitDB(config.Cfg) // Get both client and database
+
+	// Background workers stop when the server shuts down
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	workers.RunMigrations(workerCtx, db)
+	go workers.NewCertificateReminderWorker(db, config.Cfg).Run(workerCtx)
+	go workers.NewDataExportWorker(db, config.Cfg).Run(workerCtx)
+	go workers.NewAccountPurgeWorker(db, config.Cfg).Run(workerCtx)
+	go workers.NewJobLifecycleWorker(db, config.Cfg).Run(workerCtx)
 
 	// ✅ Start the match score worker properly
 	// startMatchScoreWorker(client)
@@ -63,6 +71,7 @@ func main() {
 	<-shutdownSignal
 
 	log.Println("Shutting down server...")
+	stopWorkers()
 
 	// Close MongoDB client gracefully
 	err = client.Disconnect(context.TODO()) // Disconnect the client